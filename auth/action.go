@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/yinqf/go-pkg/redis"
+)
+
+var (
+	ErrRedisNotConfigured = errors.New("auth redis client not configured")
+	ErrActionMismatch     = errors.New("token action mismatch")
+	ErrTokenUsed          = errors.New("token already used")
+)
+
+var (
+	redisMu     sync.RWMutex
+	redisClient goredis.UniversalClient
+)
+
+// UseRedis 设置令牌状态（一次性令牌、吊销等）使用的 Redis 客户端，传入 nil 表示关闭。
+func UseRedis(client goredis.UniversalClient) {
+	redisMu.Lock()
+	defer redisMu.Unlock()
+	redisClient = client
+}
+
+func getRedis() (goredis.UniversalClient, error) {
+	redisMu.RLock()
+	defer redisMu.RUnlock()
+	if redisClient == nil {
+		return nil, ErrRedisNotConfigured
+	}
+	return redisClient, nil
+}
+
+// GenerateActionToken 签发绑定用途的一次性短期令牌，适用于密码重置、魔法链接登录等场景。
+// ttl 的处理与 GenerateToken 一致。
+func GenerateActionToken(subject, action string, ttl time.Duration) (string, error) {
+	if subject == "" {
+		return "", errors.New("subject is required")
+	}
+	if action == "" {
+		return "", errors.New("action is required")
+	}
+
+	claims := newClaims(subject, effectiveTTL(ttl))
+	claims.Action = action
+	return signClaims(claims)
+}
+
+// ConsumeActionToken 校验一次性令牌的签名、用途与吊销状态，并在 Redis 中标记为已使用，重复使用返回 ErrTokenUsed。
+// 需先通过 UseRedis 配置客户端。
+func ConsumeActionToken(ctx context.Context, token, expectedAction string) (*Claims, error) {
+	client, err := getRedis()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Action == "" || claims.Action != expectedAction {
+		return nil, fmt.Errorf("%w: expected %q, got %q", ErrActionMismatch, expectedAction, claims.Action)
+	}
+	if claims.ID == "" {
+		return nil, fmt.Errorf("%w: missing jti", ErrInvalidToken)
+	}
+	if err := checkRevoked(ctx, client, claims.ID); err != nil {
+		return nil, err
+	}
+
+	ok, err := client.SetNX(ctx, usedTokenKey(claims.ID), 1, remainingTTL(claims)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("mark token used: %w", err)
+	}
+	if !ok {
+		return nil, ErrTokenUsed
+	}
+	return claims, nil
+}
+
+func usedTokenKey(jti string) string {
+	return redis.Key("auth", "used", jti)
+}
+
+// remainingTTL 返回令牌剩余有效期，标记记录只需保留到令牌过期。
+func remainingTTL(claims *Claims) time.Duration {
+	if claims.ExpiresAt == nil {
+		return 0
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return ttl
+}
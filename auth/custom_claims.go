@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// reservedClaims 为标准声明与本包使用的声明，不能通过自定义声明覆盖。
+var reservedClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"action": true, "typ": true, "att": true,
+}
+
+// GenerateTokenWithClaims 签发携带自定义声明（如 role、tenant_id）的令牌，ttl 的处理与 GenerateToken 一致。
+// extra 不能包含 sub、exp 等保留声明，否则返回错误。
+func GenerateTokenWithClaims(subject string, ttl time.Duration, extra map[string]any) (string, error) {
+	if subject == "" {
+		return "", fmt.Errorf("subject is required")
+	}
+	if err := checkExtraClaims(extra); err != nil {
+		return "", err
+	}
+
+	claims := newClaims(subject, effectiveTTL(ttl))
+	claims.Extra = copyExtraClaims(extra)
+	return signClaims(claims)
+}
+
+// checkExtraClaims 确保自定义声明不覆盖保留声明。
+func checkExtraClaims(extra map[string]any) error {
+	reserved := make([]string, 0)
+	for key := range extra {
+		if reservedClaims[key] {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) > 0 {
+		sort.Strings(reserved)
+		return fmt.Errorf("reserved claims cannot be overridden: %v", reserved)
+	}
+	return nil
+}
+
+func copyExtraClaims(extra map[string]any) map[string]any {
+	if len(extra) == 0 {
+		return nil
+	}
+	copied := make(map[string]any, len(extra))
+	for key, value := range extra {
+		copied[key] = value
+	}
+	return copied
+}
+
+// claimsJSON 与 Claims 字段相同但不带自定义编解码方法，避免递归。
+type claimsJSON Claims
+
+// MarshalJSON 将自定义声明平铺到令牌载荷顶层，保留声明不会被覆盖。
+func (c Claims) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(claimsJSON(c))
+	if err != nil || len(c.Extra) == 0 {
+		return base, err
+	}
+
+	merged := make(map[string]any, len(c.Extra)+8)
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.Extra {
+		if !reservedClaims[key] {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON 解析标准声明，并将其余声明收集到 Extra。
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var parsed claimsJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var all map[string]any
+	if err := decoder.Decode(&all); err != nil {
+		return err
+	}
+	for key := range all {
+		if reservedClaims[key] {
+			delete(all, key)
+		}
+	}
+	parsed.Extra = nil
+	if len(all) > 0 {
+		parsed.Extra = all
+	}
+
+	*c = Claims(parsed)
+	return nil
+}
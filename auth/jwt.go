@@ -9,11 +9,17 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrMissingSecret = errors.New("jwt secret not configured")
 	ErrInvalidToken  = errors.New("invalid token")
+
+	// 以下错误均包装 ErrInvalidToken，并保留 jwt 库的原始错误，可分别用 errors.Is 判断。
+	ErrTokenExpired     = fmt.Errorf("%w: token expired", ErrInvalidToken)
+	ErrTokenNotYetValid = fmt.Errorf("%w: token not valid yet", ErrInvalidToken)
+	ErrTokenMalformed   = fmt.Errorf("%w: token malformed", ErrInvalidToken)
 )
 
 const (
@@ -29,6 +35,14 @@ const claimsContextKey contextKey = "github.com/yinqf/go-pkg/auth/claims"
 // Claims 封装 jwt.RegisteredClaims，便于多服务共享鉴权信息。
 type Claims struct {
 	jwt.RegisteredClaims
+	// Action 为一次性操作令牌绑定的用途，普通令牌为空。
+	Action string `json:"action,omitempty"`
+	// Type 为令牌类型，刷新令牌为 refresh，访问令牌为空。
+	Type string `json:"typ,omitempty"`
+	// AccessTTL 为刷新令牌记录的访问令牌有效期（秒），刷新时沿用。
+	AccessTTL int64 `json:"att,omitempty"`
+	// Extra 为 GenerateTokenWithClaims 写入的自定义声明，解析时由非保留声明填充。
+	Extra map[string]any `json:"-"`
 }
 
 var (
@@ -38,19 +52,29 @@ var (
 )
 
 // GenerateToken 根据 subject 与有效期生成签名后的 JWT。
+// ttl <= 0 时使用默认有效期，超过最大有效期时截断为最大值，见 SetTTLPolicy。
 func GenerateToken(subject string, ttl time.Duration) (string, error) {
+	return signToken(subject, effectiveTTL(ttl))
+}
+
+// GenerateServiceToken 为服务间调用签发长期令牌，不受最大有效期限制，ttl <= 0 表示永不过期。
+// 仅应用于受控的内部服务凭证，面向用户的令牌请使用 GenerateToken。
+func GenerateServiceToken(subject string, ttl time.Duration) (string, error) {
+	return signToken(subject, ttl)
+}
+
+func signToken(subject string, ttl time.Duration) (string, error) {
 	if subject == "" {
 		return "", errors.New("subject is required")
 	}
+	return signClaims(newClaims(subject, ttl))
+}
 
-	secretValue, err := getSecret()
-	if err != nil {
-		return "", err
-	}
-
+func newClaims(subject string, ttl time.Duration) Claims {
 	now := time.Now().UTC()
 	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   subject,
 			Issuer:    issuerValue,
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -61,9 +85,20 @@ func GenerateToken(subject string, ttl time.Duration) (string, error) {
 	if ttl > 0 {
 		claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
 	}
+	return claims
+}
+
+func signClaims(claims Claims) (string, error) {
+	keys, err := currentKeys()
+	if err != nil {
+		return "", err
+	}
+	if keys.signKey == nil {
+		return "", ErrMissingSigningKey
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(secretValue)
+	token := jwt.NewWithClaims(keys.method, claims)
+	signed, err := token.SignedString(keys.signKey)
 	if err != nil {
 		return "", fmt.Errorf("sign token: %w", err)
 	}
@@ -71,32 +106,47 @@ func GenerateToken(subject string, ttl time.Duration) (string, error) {
 	return signed, nil
 }
 
-// ParseToken 校验签名并返回解析出的 claims。
+// ParseToken 校验签名并返回解析出的 claims，刷新令牌与一次性令牌（Action 非空）会被拒绝。
 func ParseToken(token string) (*Claims, error) {
+	claims, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type == tokenTypeRefresh {
+		err := fmt.Errorf("%w: refresh token cannot be used as access token", ErrInvalidToken)
+		notifyFailure(FailureOther, err)
+		return nil, err
+	}
+	if claims.Action != "" {
+		err := fmt.Errorf("%w: action token cannot be used as access token", ErrInvalidToken)
+		notifyFailure(FailureOther, err)
+		return nil, err
+	}
+	return claims, nil
+}
+
+func parseToken(token string) (*Claims, error) {
 	if token == "" {
-		return nil, fmt.Errorf("%w: empty token", ErrInvalidToken)
+		err := fmt.Errorf("%w: empty token", ErrTokenMalformed)
+		notifyFailure(FailureMalformed, err)
+		return nil, err
 	}
 
-	secretValue, err := getSecret()
+	keys, err := currentKeys()
 	if err != nil {
 		return nil, err
 	}
 
 	claims := &Claims{}
-	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %s", t.Method.Alg())
-		}
-		return secretValue, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	parsed, err := jwt.ParseWithClaims(token, claims, keys.verifyKeyFor, jwt.WithValidMethods([]string{keys.method.Alg()}), jwt.WithLeeway(currentLeeway()))
 	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, err
-		}
-		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		reason := classifyFailure(parsed, keys.method.Alg(), err)
+		notifyFailure(reason, err)
+		return nil, fmt.Errorf("%w: %w", failureError(reason), err)
 	}
 
 	if !parsed.Valid {
+		notifyFailure(FailureOther, ErrInvalidToken)
 		return nil, ErrInvalidToken
 	}
 
@@ -120,6 +170,12 @@ func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
 	return claims, ok
 }
 
+// CheckSecret 检查签名密钥是否已配置（Configure 或 JWT_SECRET），便于启动时快速失败。
+func CheckSecret() error {
+	_, err := currentKeys()
+	return err
+}
+
 func getSecret() ([]byte, error) {
 	secretOnce.Do(func() {
 		value := os.Getenv("JWT_SECRET")
@@ -133,9 +189,13 @@ func getSecret() ([]byte, error) {
 	return secret, secretErr
 }
 
-// ResetCacheForTest 清理缓存的密钥，便于测试重新配置环境变量。
+// ResetCacheForTest 清理缓存的密钥与 Configure 设置的配置，便于测试重新配置环境变量。
 func ResetCacheForTest() {
 	secretOnce = sync.Once{}
 	secret = nil
 	secretErr = nil
+
+	keysMu.Lock()
+	configured = nil
+	keysMu.Unlock()
 }
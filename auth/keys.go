@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrMissingSigningKey 表示当前配置只有验签公钥，无法签发令牌。
+var ErrMissingSigningKey = errors.New("jwt signing key not configured")
+
+// Config 描述签名算法与密钥。Algorithm 为 HS256 时使用 Secret；RS256/ES256 使用 PEM 编码的私钥与公钥，
+// 仅提供公钥时只能校验令牌，仅提供私钥时公钥由私钥推导。
+type Config struct {
+	Algorithm string
+	Secret    []byte
+	// PreviousSecrets 为轮换窗口内仍可用于验签的旧密钥，签发始终使用 Secret。
+	PreviousSecrets [][]byte
+	PrivateKeyPEM   []byte
+	PublicKeyPEM    []byte
+}
+
+// keySet 为解析后的签名方法与密钥。
+type keySet struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+var (
+	keysMu     sync.RWMutex
+	configured *keySet
+)
+
+// Configure 设置签名算法与密钥，覆盖默认的 JWT_SECRET + HS256；ParseToken 只接受与配置一致的算法。
+func Configure(cfg Config) error {
+	keys, err := parseConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	configured = keys
+	return nil
+}
+
+func parseConfig(cfg Config) (*keySet, error) {
+	switch strings.ToUpper(strings.TrimSpace(cfg.Algorithm)) {
+	case "", jwt.SigningMethodHS256.Alg():
+		return hmacKeys(cfg.Secret, cfg.PreviousSecrets)
+	case jwt.SigningMethodRS256.Alg():
+		return parseRSAKeys(cfg)
+	case jwt.SigningMethodES256.Alg():
+		return parseECKeys(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q", cfg.Algorithm)
+	}
+}
+
+// SetSecret 在运行时替换 HS256 签名密钥，无需重启进程；previous 中的旧密钥在轮换窗口内仍可验签，
+// 使旧密钥签发的令牌在过期前继续有效。
+func SetSecret(secret []byte, previous ...[]byte) error {
+	return Configure(Config{Algorithm: jwt.SigningMethodHS256.Alg(), Secret: secret, PreviousSecrets: previous})
+}
+
+// hmacKeys 生成 HS256 密钥集合，存在旧密钥时验签依次尝试当前密钥与旧密钥。
+func hmacKeys(secret []byte, previous [][]byte) (*keySet, error) {
+	if len(secret) == 0 {
+		return nil, ErrMissingSecret
+	}
+	keys := &keySet{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+
+	verifyKeys := []jwt.VerificationKey{secret}
+	for _, old := range previous {
+		if len(old) > 0 {
+			verifyKeys = append(verifyKeys, old)
+		}
+	}
+	if len(verifyKeys) > 1 {
+		keys.verifyKey = jwt.VerificationKeySet{Keys: verifyKeys}
+	}
+	return keys, nil
+}
+
+func parseRSAKeys(cfg Config) (*keySet, error) {
+	keys := &keySet{method: jwt.SigningMethodRS256}
+	if len(cfg.PrivateKeyPEM) > 0 {
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(cfg.PrivateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse rsa private key: %w", err)
+		}
+		keys.signKey = private
+		keys.verifyKey = &private.PublicKey
+	}
+	if len(cfg.PublicKeyPEM) > 0 {
+		public, err := jwt.ParseRSAPublicKeyFromPEM(cfg.PublicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse rsa public key: %w", err)
+		}
+		keys.verifyKey = public
+	}
+	if keys.verifyKey == nil {
+		return nil, errors.New("rsa private or public key is required")
+	}
+	return keys, nil
+}
+
+func parseECKeys(cfg Config) (*keySet, error) {
+	keys := &keySet{method: jwt.SigningMethodES256}
+	if len(cfg.PrivateKeyPEM) > 0 {
+		private, err := jwt.ParseECPrivateKeyFromPEM(cfg.PrivateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse ec private key: %w", err)
+		}
+		if private.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires a P-256 key")
+		}
+		keys.signKey = private
+		keys.verifyKey = &private.PublicKey
+	}
+	if len(cfg.PublicKeyPEM) > 0 {
+		public, err := jwt.ParseECPublicKeyFromPEM(cfg.PublicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse ec public key: %w", err)
+		}
+		if public.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires a P-256 key")
+		}
+		keys.verifyKey = public
+	}
+	if keys.verifyKey == nil {
+		return nil, errors.New("ec private or public key is required")
+	}
+	return keys, nil
+}
+
+// currentKeys 返回 Configure 设置的密钥；未配置时回退到 JWT_SECRET + HS256。
+func currentKeys() (*keySet, error) {
+	keysMu.RLock()
+	keys := configured
+	keysMu.RUnlock()
+	if keys != nil {
+		return keys, nil
+	}
+
+	secretValue, err := getSecret()
+	if err != nil {
+		return nil, err
+	}
+	return &keySet{method: jwt.SigningMethodHS256, signKey: secretValue, verifyKey: secretValue}, nil
+}
+
+// verifyKeyFor 返回与令牌算法匹配的验签密钥，算法不一致时拒绝。
+func (k *keySet) verifyKeyFor(t *jwt.Token) (interface{}, error) {
+	if t.Method == nil || t.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return k.verifyKey, nil
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// init 向 logger 注册 subject 提取器，logger.InfoCtx 等函数会自动附加已认证用户的 subject。
+func init() {
+	logger.RegisterContextExtractor(subjectFields)
+}
+
+func subjectFields(ctx context.Context) []zap.Field {
+	if claims, ok := ClaimsFromContext(ctx); ok && claims != nil && claims.Subject != "" {
+		return []zap.Field{zap.String("subject", claims.Subject)}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yinqf/go-pkg/response"
+)
+
+const (
+	defaultAuthHeader = "Authorization"
+	defaultAuthScheme = "Bearer"
+)
+
+// MiddlewareOption 用于定制 Middleware 读取令牌的方式。
+type MiddlewareOption func(*middlewareConfig)
+
+type middlewareConfig struct {
+	header string
+	scheme string
+}
+
+// WithHeader 设置读取令牌的请求头，默认为 Authorization。
+func WithHeader(name string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		if name = strings.TrimSpace(name); name != "" {
+			cfg.header = name
+		}
+	}
+}
+
+// WithScheme 设置令牌前缀，默认为 Bearer；传入空字符串表示请求头直接为令牌。
+func WithScheme(scheme string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.scheme = strings.TrimSpace(scheme)
+	}
+}
+
+// Middleware 校验请求头中的 JWT（配置了 UseRedis 时同时检查吊销），成功时通过 ContextWithClaims 写入请求上下文，失败时以 401 响应并终止后续处理。
+func Middleware(opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := middlewareConfig{header: defaultAuthHeader, scheme: defaultAuthScheme}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return func(c *gin.Context) {
+		token, ok := extractToken(c.GetHeader(cfg.header), cfg.scheme)
+		if !ok {
+			unauthorized(c, "缺少访问令牌")
+			return
+		}
+
+		claims, err := parseRequestToken(c, token)
+		if err != nil {
+			if errors.Is(err, ErrTokenExpired) {
+				unauthorized(c, "访问令牌已过期")
+				return
+			}
+			if errors.Is(err, ErrTokenNotYetValid) {
+				unauthorized(c, "访问令牌尚未生效")
+				return
+			}
+			if errors.Is(err, ErrTokenRevoked) {
+				unauthorized(c, "访问令牌已失效")
+				return
+			}
+			if errors.Is(err, ErrMissingSecret) || errors.Is(err, ErrMissingSigningKey) {
+				response.ErrorWithStatus(c, http.StatusInternalServerError, err.Error())
+				c.Abort()
+				return
+			}
+			unauthorized(c, "访问令牌无效")
+			return
+		}
+
+		c.Request = c.Request.WithContext(ContextWithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
+
+// parseRequestToken 在配置了 Redis 时同时检查吊销状态。
+func parseRequestToken(c *gin.Context, token string) (*Claims, error) {
+	if _, err := getRedis(); err != nil {
+		return ParseToken(token)
+	}
+	return ParseTokenWithRevocation(c.Request.Context(), token)
+}
+
+// extractToken 从请求头中去掉 scheme 前缀（大小写不敏感），返回令牌。
+func extractToken(value, scheme string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+	if scheme == "" {
+		return value, true
+	}
+
+	prefix, token, found := strings.Cut(value, " ")
+	if !found || !strings.EqualFold(prefix, scheme) {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(c *gin.Context, msg string) {
+	response.ErrorWithStatus(c, http.StatusUnauthorized, msg)
+	c.Abort()
+}
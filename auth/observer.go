@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// FailureReason 描述令牌解析失败的分类，便于上报安全监控指标。
+type FailureReason string
+
+const (
+	FailureExpired       FailureReason = "expired"
+	FailureNotYetValid   FailureReason = "not_yet_valid"
+	FailureBadSignature  FailureReason = "bad_signature"
+	FailureMalformed     FailureReason = "malformed"
+	FailureUnexpectedAlg FailureReason = "unexpected_alg"
+	FailureOther         FailureReason = "other"
+)
+
+// FailureObserver 在 ParseToken 失败时被调用。
+type FailureObserver func(reason FailureReason, err error)
+
+var (
+	observerMu sync.RWMutex
+	observer   FailureObserver
+)
+
+// SetFailureObserver 注册令牌解析失败的观察者，传入 nil 表示关闭。
+func SetFailureObserver(fn FailureObserver) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	observer = fn
+}
+
+func notifyFailure(reason FailureReason, err error) {
+	observerMu.RLock()
+	fn := observer
+	observerMu.RUnlock()
+
+	if fn != nil {
+		fn(reason, err)
+	}
+}
+
+// classifyFailure 根据 jwt 库返回的错误与已解析的头部判断失败原因，expectedAlg 为当前配置的签名算法。
+func classifyFailure(parsed *jwt.Token, expectedAlg string, err error) FailureReason {
+	if parsed != nil && parsed.Method != nil && parsed.Method.Alg() != expectedAlg {
+		return FailureUnexpectedAlg
+	}
+
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return FailureExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return FailureNotYetValid
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return FailureBadSignature
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return FailureMalformed
+	case errors.Is(err, jwt.ErrTokenUnverifiable):
+		return FailureUnexpectedAlg
+	default:
+		return FailureOther
+	}
+}
+
+// failureError 将失败原因映射为对外的哨兵错误。
+func failureError(reason FailureReason) error {
+	switch reason {
+	case FailureExpired:
+		return ErrTokenExpired
+	case FailureNotYetValid:
+		return ErrTokenNotYetValid
+	case FailureMalformed:
+		return ErrTokenMalformed
+	default:
+		return ErrInvalidToken
+	}
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// tokenTypeRefresh 为刷新令牌的 typ 声明值。
+	tokenTypeRefresh = "refresh"
+
+	defaultRefreshTTL = 7 * 24 * time.Hour
+)
+
+// ErrNotRefreshToken 表示传入 RefreshToken 的不是刷新令牌。
+var ErrNotRefreshToken = errors.New("not a refresh token")
+
+// TokenPair 为访问令牌与刷新令牌组合。
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// GenerateTokenPair 签发短期访问令牌与长期刷新令牌，刷新令牌带有 typ=refresh 声明，不能作为访问令牌使用。
+// accessTTL 的处理与 GenerateToken 一致；refreshTTL <= 0 时默认 7 天，同样受最大有效期限制。
+func GenerateTokenPair(subject string, accessTTL, refreshTTL time.Duration) (TokenPair, error) {
+	return GenerateTokenPairWithClaims(subject, accessTTL, refreshTTL, nil)
+}
+
+// GenerateTokenPairWithClaims 与 GenerateTokenPair 相同，两个令牌均携带自定义声明，刷新时一并沿用。
+func GenerateTokenPairWithClaims(subject string, accessTTL, refreshTTL time.Duration, extra map[string]any) (TokenPair, error) {
+	if subject == "" {
+		return TokenPair{}, errors.New("subject is required")
+	}
+	if err := checkExtraClaims(extra); err != nil {
+		return TokenPair{}, err
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTTL
+	}
+	accessTTL = effectiveTTL(accessTTL)
+
+	accessClaims := newClaims(subject, accessTTL)
+	accessClaims.Extra = copyExtraClaims(extra)
+	access, err := signClaims(accessClaims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	claims := newClaims(subject, effectiveTTL(refreshTTL))
+	claims.Type = tokenTypeRefresh
+	claims.AccessTTL = int64(accessTTL / time.Second)
+	claims.Extra = copyExtraClaims(extra)
+	refresh, err := signClaims(claims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// RefreshToken 校验刷新令牌并为同一 subject 签发新的令牌对，新令牌沿用原有的有效时长与自定义声明。
+// 通过 UseRedis 配置客户端后，已被 Revoke 吊销的刷新令牌会被拒绝，且每个刷新令牌只能成功轮换一次，
+// 旧令牌在签发新令牌对时即被吊销，重放返回 ErrTokenRevoked。
+// 未配置 Redis 时无法感知吊销，旧刷新令牌在过期前仍可重复使用。
+func RefreshToken(ctx context.Context, refresh string) (TokenPair, error) {
+	claims, err := parseToken(refresh)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if claims.Type != tokenTypeRefresh {
+		return TokenPair{}, ErrNotRefreshToken
+	}
+	if claims.Subject == "" {
+		return TokenPair{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	var refreshTTL time.Duration
+	if claims.ExpiresAt != nil && claims.IssuedAt != nil {
+		refreshTTL = claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	}
+	accessTTL := time.Duration(claims.AccessTTL) * time.Second
+
+	pair, err := GenerateTokenPairWithClaims(claims.Subject, accessTTL, refreshTTL, claims.Extra)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	client, err := getRedis()
+	if err != nil || claims.ID == "" {
+		return pair, nil
+	}
+	// SetNX 同时完成吊销检查与旧令牌吊销，并发刷新时只有一个请求成功。
+	rotated, err := client.SetNX(ctx, revokedTokenKey(claims.ID), 1, remainingTTL(claims)).Result()
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("revoke refresh token: %w", err)
+	}
+	if !rotated {
+		return TokenPair{}, ErrTokenRevoked
+	}
+	return pair, nil
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/yinqf/go-pkg/redis"
+)
+
+// ErrTokenRevoked 表示令牌已被吊销。
+var ErrTokenRevoked = errors.New("token revoked")
+
+// Revoke 吊销令牌（如退出登录、账号泄露），在 Redis 中记录其 jti 直到令牌过期；已过期的令牌无需吊销。
+// 需先通过 UseRedis 配置客户端。
+func Revoke(ctx context.Context, token string) error {
+	client, err := getRedis()
+	if err != nil {
+		return err
+	}
+
+	claims, err := parseToken(token)
+	if err != nil {
+		if errors.Is(err, ErrTokenExpired) {
+			return nil
+		}
+		return err
+	}
+	if claims.ID == "" {
+		return fmt.Errorf("%w: missing jti", ErrInvalidToken)
+	}
+
+	if err := client.Set(ctx, revokedTokenKey(claims.ID), 1, remainingTTL(claims)).Err(); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+// ParseTokenWithRevocation 在 ParseToken 的基础上检查令牌是否已被吊销，需先通过 UseRedis 配置客户端。
+func ParseTokenWithRevocation(ctx context.Context, token string) (*Claims, error) {
+	client, err := getRedis()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ID == "" {
+		return claims, nil
+	}
+	if err := checkRevoked(ctx, client, claims.ID); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkRevoked 检查 jti 是否已被吊销，已吊销时返回 ErrTokenRevoked。
+func checkRevoked(ctx context.Context, client goredis.UniversalClient, jti string) error {
+	revoked, err := client.Exists(ctx, revokedTokenKey(jti)).Result()
+	if err != nil {
+		return fmt.Errorf("check token revocation: %w", err)
+	}
+	if revoked > 0 {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+func revokedTokenKey(jti string) string {
+	return redis.Key("auth", "revoked", jti)
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenTTL    = 2 * time.Hour
+	defaultMaxTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ttlMu       sync.RWMutex
+	tokenTTL    = defaultTokenTTL
+	maxTokenTTL = defaultMaxTokenTTL
+	leeway      time.Duration
+)
+
+// SetTTLPolicy 设置 GenerateToken 的默认有效期与最大有效期，非正值保持原配置。
+// 默认有效期为 2 小时，最大有效期为 30 天。
+func SetTTLPolicy(defaultTTL, maxTTL time.Duration) {
+	ttlMu.Lock()
+	defer ttlMu.Unlock()
+
+	if maxTTL > 0 {
+		maxTokenTTL = maxTTL
+	}
+	if defaultTTL > 0 {
+		tokenTTL = defaultTTL
+	}
+	if tokenTTL > maxTokenTTL {
+		tokenTTL = maxTokenTTL
+	}
+}
+
+// effectiveTTL 将 ttl <= 0 替换为默认有效期，并截断到最大有效期。
+func effectiveTTL(ttl time.Duration) time.Duration {
+	ttlMu.RLock()
+	defer ttlMu.RUnlock()
+
+	if ttl <= 0 {
+		return tokenTTL
+	}
+	if ttl > maxTokenTTL {
+		return maxTokenTTL
+	}
+	return ttl
+}
+
+// SetLeeway 设置校验 exp/nbf/iat 时容忍的时钟偏差，默认为 0。
+// 多机部署时钟不完全同步时，设置 30s 左右的偏差可避免刚签发的令牌被误判为 ErrTokenNotValidYet。
+func SetLeeway(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	ttlMu.Lock()
+	defer ttlMu.Unlock()
+	leeway = d
+}
+
+func currentLeeway() time.Duration {
+	ttlMu.RLock()
+	defer ttlMu.RUnlock()
+	return leeway
+}
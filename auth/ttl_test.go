@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestSetLeewayAcceptsFutureNotBefore(t *testing.T) {
+	if err := SetSecret([]byte("leeway-test-secret")); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	t.Cleanup(func() { SetLeeway(0) })
+
+	claims := newClaims("user-1", time.Minute)
+	claims.NotBefore = jwt.NewNumericDate(time.Now().Add(10 * time.Second))
+	token, err := signClaims(claims)
+	if err != nil {
+		t.Fatalf("signClaims: %v", err)
+	}
+
+	SetLeeway(0)
+	if _, err := ParseToken(token); !errors.Is(err, jwt.ErrTokenNotValidYet) {
+		t.Fatalf("ParseToken without leeway: got %v, want ErrTokenNotValidYet", err)
+	}
+
+	SetLeeway(30 * time.Second)
+	parsed, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken with leeway: %v", err)
+	}
+	if parsed.Subject != "user-1" {
+		t.Fatalf("subject = %q, want user-1", parsed.Subject)
+	}
+}
@@ -0,0 +1,92 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidColumn 表示列不存在或不在白名单内。
+var ErrInvalidColumn = errors.New("invalid column")
+
+// GroupCount 按指定列分组统计满足筛选条件的记录数，NULL 值归入空字符串键。
+func (s *Service[T]) GroupCount(ctx context.Context, column string, filters map[string][]string) (map[string]int64, error) {
+	column = strings.TrimSpace(column)
+	if err := s.checkLimits(filters, nil); err != nil {
+		return nil, err
+	}
+	query, allowed := s.listQuery(ctx, filters)
+	if column == "" || !allowed[column] {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidColumn, column)
+	}
+
+	var rows []struct {
+		GroupKey sql.NullString
+		Total    int64
+	}
+	columnExpr := clause.Column{Name: column}
+	err := s.guard(func() error {
+		return query.Select("? AS group_key, COUNT(*) AS total", columnExpr).
+			Clauses(clause.GroupBy{Columns: []clause.Column{columnExpr}}).
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GroupKey.String] += row.Total
+	}
+	return counts, nil
+}
+
+// ErrInvalidAggregate 表示不支持的聚合函数。
+var ErrInvalidAggregate = errors.New("invalid aggregate function")
+
+// aggregateFuncs 为允许的聚合函数白名单。
+var aggregateFuncs = map[string]string{
+	"sum":   "SUM",
+	"avg":   "AVG",
+	"min":   "MIN",
+	"max":   "MAX",
+	"count": "COUNT",
+}
+
+// Aggregate 对满足筛选条件的记录计算 sum/avg/min/max/count，没有记录时返回 0。
+// 除 count 外，列必须为数值类型。
+func (s *Service[T]) Aggregate(ctx context.Context, fn string, column string, filters map[string][]string) (float64, error) {
+	sqlFunc, ok := aggregateFuncs[strings.ToLower(strings.TrimSpace(fn))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAggregate, fn)
+	}
+	if err := s.checkLimits(filters, nil); err != nil {
+		return 0, err
+	}
+
+	column = strings.TrimSpace(column)
+	query, allowed := s.listQuery(ctx, filters)
+	if column == "" || !allowed[column] {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidColumn, column)
+	}
+	if sqlFunc != "COUNT" {
+		field := lookupFilterField(query, column)
+		if field == nil || (field.DataType != schema.Int && field.DataType != schema.Uint && field.DataType != schema.Float) {
+			return 0, fmt.Errorf("%w: %s is not numeric", ErrInvalidColumn, column)
+		}
+	}
+
+	var result sql.NullFloat64
+	err := s.guard(func() error {
+		return query.Select(sqlFunc+"(?)", clause.Column{Name: column}).Scan(&result).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.Float64, nil
+}
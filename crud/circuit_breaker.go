@@ -0,0 +1,201 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrCircuitOpen 表示熔断器处于打开状态，请求被快速失败。
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	defaultBreakerFailureRate = 0.5
+	defaultBreakerMinRequests = 20
+	defaultBreakerWindow      = 10 * time.Second
+	defaultBreakerCooldown    = 30 * time.Second
+)
+
+// BreakerConfig 配置数据库调用的熔断策略，零值字段使用默认值。
+type BreakerConfig struct {
+	// FailureRate 为窗口内触发熔断的失败率，取值 (0, 1]，默认 0.5。
+	FailureRate float64
+	// MinRequests 为窗口内参与统计的最少请求数，默认 20。
+	MinRequests int
+	// Window 为失败率统计窗口，默认 10 秒。
+	Window time.Duration
+	// Cooldown 为熔断打开后的冷却时间，结束后放行一个探测请求，默认 30 秒。
+	Cooldown time.Duration
+}
+
+// WithCircuitBreaker 为服务的数据库调用启用熔断器，仅基础设施错误计入失败，记录不存在等业务错误不计入。
+func WithCircuitBreaker[T any](cfg BreakerConfig) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.breaker = newCircuitBreaker(cfg)
+	}
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type circuitBreaker struct {
+	mu          sync.Mutex
+	cfg         BreakerConfig
+	state       breakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	if cfg.FailureRate <= 0 || cfg.FailureRate > 1 {
+		cfg.FailureRate = defaultBreakerFailureRate
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaultBreakerMinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultBreakerWindow
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultBreakerCooldown
+	}
+	return &circuitBreaker{cfg: cfg}
+}
+
+// allow 判断是否放行请求；冷却结束后进入半开状态，仅放行一个探测请求。
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.state == breakerHalfOpen {
+		b.probing = false
+		if failed {
+			b.state = breakerOpen
+			b.openedAt = now
+			return
+		}
+		b.reset(breakerClosed, now)
+		return
+	}
+
+	if now.Sub(b.windowStart) > b.cfg.Window {
+		b.windowStart = now
+		b.requests = 0
+		b.failures = 0
+	}
+
+	b.requests++
+	if failed {
+		b.failures++
+	}
+
+	if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRate {
+		b.reset(breakerOpen, now)
+		b.openedAt = now
+	}
+}
+
+func (b *circuitBreaker) reset(state breakerState, now time.Time) {
+	b.state = state
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+// guard 在熔断器保护下执行数据库调用。fn 发生 panic 时按失败记录后继续向上传播，避免半开状态的探测标记无法复位。
+func (s *Service[T]) guard(fn func() error) error {
+	if s.breaker == nil {
+		return fn()
+	}
+	if !s.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	failed := true
+	defer func() { s.breaker.record(failed) }()
+
+	err := fn()
+	failed = isInfrastructureError(s.translateError(err))
+	return err
+}
+
+// translateError 借助方言把驱动错误（如 MySQL 1062）转换为 gorm.ErrDuplicatedKey 等通用错误，仅用于熔断分类。
+func (s *Service[T]) translateError(err error) error {
+	if err == nil || s.db == nil {
+		return err
+	}
+	if translator, ok := s.db.Dialector.(gorm.ErrorTranslator); ok {
+		if translated := translator.Translate(err); translated != nil {
+			return translated
+		}
+	}
+	return err
+}
+
+// clientErrors 为调用方参数或数据本身导致的错误，不代表数据库不可用。
+var clientErrors = []error{
+	gorm.ErrRecordNotFound,
+	gorm.ErrDuplicatedKey,
+	gorm.ErrForeignKeyViolated,
+	ErrInvalidFilter,
+	ErrInvalidID,
+	ErrInvalidColumn,
+	ErrInvalidAggregate,
+	ErrInvalidCollation,
+	ErrInvalidCursor,
+	ErrInvalidPatch,
+	ErrTooManyFilters,
+	ErrTooManyOrders,
+	ErrSoftDeleteUnsupported,
+	ErrForbidden,
+	ErrCircuitOpen,
+	context.Canceled,
+}
+
+// isInfrastructureError 判断错误是否来源于数据库基础设施，本包的哨兵错误、唯一键冲突等业务语义错误与调用方取消不计入熔断统计。
+func isInfrastructureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, target := range clientErrors {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package crud
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGuardRecordsPanicDuringProbe(t *testing.T) {
+	svc := NewService[pageItem](nil, WithCircuitBreaker[pageItem](BreakerConfig{MinRequests: 1, Cooldown: time.Millisecond}))
+
+	if err := svc.guard(func() error { return errors.New("connection refused") }); err == nil {
+		t.Fatal("expected the infrastructure error")
+	}
+	if err := svc.guard(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after failure: got %v, want ErrCircuitOpen", err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the probe panic to propagate")
+			}
+		}()
+		_ = svc.guard(func() error { panic("probe failed") })
+	}()
+
+	time.Sleep(2 * time.Millisecond)
+	if err := svc.guard(func() error { return nil }); err != nil {
+		t.Fatalf("probe after panic: got %v, want the breaker to allow a new probe", err)
+	}
+}
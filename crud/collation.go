@@ -0,0 +1,72 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidCollation 表示排序指定的排序规则不在白名单内。
+var ErrInvalidCollation = errors.New("invalid collation")
+
+// WithCollations 设置排序时允许使用的排序规则白名单，例如 utf8mb4_unicode_ci、utf8mb4_turkish_ci。
+// 名称仅允许字母、数字与下划线，其余名称会被忽略。
+func WithCollations[T any](names ...string) ServiceOption[T] {
+	return func(s *Service[T]) {
+		for _, name := range names {
+			trimmed := strings.TrimSpace(name)
+			if !isCollationName(trimmed) {
+				continue
+			}
+			if s.collations == nil {
+				s.collations = make(map[string]bool, len(names))
+			}
+			s.collations[strings.ToLower(trimmed)] = true
+		}
+	}
+}
+
+// orderBy 为排序列追加 ORDER BY；指定了排序规则时输出 col COLLATE name，规则须已通过 checkCollation 校验。
+func (s *Service[T]) orderBy(query *gorm.DB, opt OrderOption) *gorm.DB {
+	column := clause.Column{Name: opt.Column}
+	if opt.Collation == "" {
+		return query.Order(clause.OrderByColumn{Column: column, Desc: opt.Desc})
+	}
+
+	collation := strings.ToLower(opt.Collation)
+	if err := s.checkCollation(opt.Collation); err != nil {
+		// checkLimits 已提前校验，此处仅作兜底。
+		_ = query.AddError(err)
+		return query
+	}
+
+	expr := clause.Column{Name: query.Statement.Quote(column) + " COLLATE " + collation, Raw: true}
+	return query.Order(clause.OrderByColumn{Column: expr, Desc: opt.Desc})
+}
+
+// checkCollation 校验排序规则是否在白名单内，在访问数据库之前调用，避免无效参数计入熔断统计。
+func (s *Service[T]) checkCollation(name string) error {
+	collation := strings.ToLower(strings.TrimSpace(name))
+	if collation == "" {
+		return nil
+	}
+	if !isCollationName(collation) || !s.collations[collation] {
+		return fmt.Errorf("%w: %s", ErrInvalidCollation, name)
+	}
+	return nil
+}
+
+func isCollationName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
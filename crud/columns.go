@@ -0,0 +1,57 @@
+package crud
+
+import "strings"
+
+// ColumnInfo 描述实体列的元数据，供前端动态生成表单与表格。
+type ColumnInfo struct {
+	Name       string `json:"name"`
+	Field      string `json:"field"`
+	GoType     string `json:"go_type"`
+	DBType     string `json:"db_type"`
+	Nullable   bool   `json:"nullable"`
+	PrimaryKey bool   `json:"primary_key"`
+	Sortable   bool   `json:"sortable"`
+	Filterable bool   `json:"filterable"`
+}
+
+// Columns 返回实体各列的元数据，可排序/可筛选标记与 Paginate 使用的白名单一致。
+func (s *Service[T]) Columns() []ColumnInfo {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(sch.Fields))
+	for _, field := range sch.Fields {
+		if field.DBName != "" && columnNamePattern.MatchString(field.DBName) {
+			allowed[field.DBName] = true
+		}
+	}
+	allowed = s.filterableColumns(allowed)
+	sortable := s.sortableColumns(allowed)
+
+	columns := make([]ColumnInfo, 0, len(sch.DBNames))
+	for _, name := range sch.DBNames {
+		field := sch.FieldsByDBName[name]
+		if field == nil {
+			continue
+		}
+
+		dbType := strings.TrimSpace(field.TagSettings["TYPE"])
+		if dbType == "" {
+			dbType = string(field.DataType)
+		}
+
+		columns = append(columns, ColumnInfo{
+			Name:       field.DBName,
+			Field:      field.Name,
+			GoType:     field.FieldType.String(),
+			DBType:     dbType,
+			Nullable:   !field.NotNull && !field.PrimaryKey,
+			PrimaryKey: field.PrimaryKey,
+			Sortable:   sortable[field.DBName],
+			Filterable: allowed[field.DBName],
+		})
+	}
+	return columns
+}
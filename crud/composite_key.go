@@ -0,0 +1,131 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// DeleteByKeys 按完整主键删除记录，适用于 (tenant_id, id) 等联合主键；keys 必须且只能包含全部主键列。
+func (s *Service[T]) DeleteByKeys(ctx context.Context, keys map[string]string) error {
+	condition, err := s.keysCondition(keys)
+	if err != nil {
+		return err
+	}
+	return s.deleteWhere(ctx, condition, keys)
+}
+
+// idCondition 将路径中的 id 转换为主键条件：单主键直接解析，联合主键使用 tenant_id:1,id:42 格式。
+func (s *Service[T]) idCondition(id string) (clause.Expression, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sch.PrimaryFields) > 1 {
+		keys, err := parseCompositeID(id)
+		if err != nil {
+			return nil, err
+		}
+		return s.keysCondition(keys)
+	}
+
+	primary, err := s.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+	key, err := parsePrimaryKey(primary, id)
+	if err != nil {
+		return nil, err
+	}
+	return clause.Eq{Column: clause.Column{Name: primary.DBName}, Value: key}, nil
+}
+
+// idsCondition 将多个 id 转换为主键条件：单主键使用 IN，联合主键逐个解析后以 OR 组合。
+func (s *Service[T]) idsCondition(ids []string) (clause.Expression, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sch.PrimaryFields) > 1 {
+		conditions := make([]clause.Expression, 0, len(ids))
+		for _, id := range ids {
+			condition, err := s.idCondition(id)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		return clause.Or(conditions...), nil
+	}
+
+	primary, err := s.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		key, err := parsePrimaryKey(primary, id)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, key)
+	}
+	return clause.IN{Column: clause.Column{Name: primary.DBName}, Values: values}, nil
+}
+
+// keysCondition 校验 keys 恰好覆盖全部主键列，并按字段类型解析各列的值。
+func (s *Service[T]) keysCondition(keys map[string]string) (clause.Expression, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+	if len(sch.PrimaryFields) == 0 {
+		return nil, fmt.Errorf("primary key is not defined")
+	}
+
+	for name := range keys {
+		field := sch.LookUpField(name)
+		if field == nil || !field.PrimaryKey {
+			return nil, fmt.Errorf("%w: %s is not a primary key column", ErrInvalidID, name)
+		}
+	}
+
+	conditions := make([]clause.Expression, 0, len(sch.PrimaryFields))
+	for _, field := range sch.PrimaryFields {
+		raw, ok := keys[field.DBName]
+		if !ok {
+			raw, ok = keys[field.Name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: missing primary key %s", ErrInvalidID, field.DBName)
+		}
+		value, err := parsePrimaryKey(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, clause.Eq{Column: clause.Column{Name: field.DBName}, Value: value})
+	}
+	return clause.And(conditions...), nil
+}
+
+// parseCompositeID 解析 tenant_id:1,id:42 形式的联合主键，列名重复或格式错误时返回 ErrInvalidID。
+func parseCompositeID(id string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, part := range strings.Split(id, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), ":")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("%w: composite id %q must look like col:value,col:value", ErrInvalidID, id)
+		}
+		if _, exists := keys[name]; exists {
+			return nil, fmt.Errorf("%w: duplicate key %s in composite id", ErrInvalidID, name)
+		}
+		keys[name] = value
+	}
+	return keys, nil
+}
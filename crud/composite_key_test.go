@@ -0,0 +1,98 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type tenantOrder struct {
+	TenantID  uint `gorm:"primaryKey;autoIncrement:false"`
+	ID        uint `gorm:"primaryKey;autoIncrement:false"`
+	Note      string
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+func seedTenantOrders(t *testing.T) (*gorm.DB, *Service[tenantOrder]) {
+	t.Helper()
+	db := newTestDB(t, &tenantOrder{})
+	orders := []tenantOrder{
+		{TenantID: 1, ID: 42, Note: "tenant 1"},
+		{TenantID: 2, ID: 42, Note: "tenant 2"},
+	}
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	return db, NewService[tenantOrder](db)
+}
+
+func TestFindByIDCompositeKey(t *testing.T) {
+	_, svc := seedTenantOrders(t)
+	ctx := context.Background()
+
+	order, err := svc.FindByID(ctx, "tenant_id:2,id:42")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if order.TenantID != 2 || order.Note != "tenant 2" {
+		t.Fatalf("got %+v, want tenant 2 row", order)
+	}
+
+	if _, err := svc.FindByID(ctx, "42"); !errors.Is(err, ErrInvalidID) {
+		t.Fatalf("FindByID with a partial key: got %v, want ErrInvalidID", err)
+	}
+	if _, err := svc.FindByID(ctx, "tenant_id:3,id:42"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("FindByID other tenant: got %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestTouchCompositeKey(t *testing.T) {
+	db, svc := seedTenantOrders(t)
+	stale := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := db.Model(&tenantOrder{}).Where("1 = 1").UpdateColumn("updated_at", stale).Error; err != nil {
+		t.Fatalf("reset updated_at: %v", err)
+	}
+
+	if err := svc.Touch(context.Background(), "tenant_id:1,id:42"); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+
+	var orders []tenantOrder
+	if err := db.Order("tenant_id").Find(&orders).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !orders[0].UpdatedAt.After(stale) {
+		t.Fatalf("tenant 1 updated_at was not refreshed: %v", orders[0].UpdatedAt)
+	}
+	if !orders[1].UpdatedAt.Equal(stale) {
+		t.Fatalf("tenant 2 updated_at changed: %v", orders[1].UpdatedAt)
+	}
+}
+
+func TestRestoreByIDsCompositeKey(t *testing.T) {
+	db, svc := seedTenantOrders(t)
+	ctx := context.Background()
+	if err := db.Where("1 = 1").Delete(&tenantOrder{}).Error; err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+
+	restored, err := svc.RestoreByIDs(ctx, []string{"tenant_id:2,id:42"})
+	if err != nil {
+		t.Fatalf("RestoreByIDs: %v", err)
+	}
+	if restored != 1 {
+		t.Fatalf("restored = %d, want 1", restored)
+	}
+
+	var visible []tenantOrder
+	if err := db.Find(&visible).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(visible) != 1 || visible[0].TenantID != 2 {
+		t.Fatalf("visible rows = %+v, want only tenant 2", visible)
+	}
+}
@@ -0,0 +1,82 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// CountMode 控制 Paginate 如何计算总数。
+type CountMode int
+
+const (
+	// CountExact 执行 COUNT(*) 得到精确总数，为默认行为。
+	CountExact CountMode = iota
+	// CountSkip 跳过总数查询，total 返回 -1，适用于只需“下一页”的无限滚动列表。
+	CountSkip
+	// CountApprox 在无筛选条件且未配置强制作用域与自定义软删除列时，读取数据库统计信息中的估算行数
+	// （MySQL 的 information_schema.TABLES.TABLE_ROWS、Postgres 的 pg_class.reltuples）。
+	// 估算值可能与实际相差较大且包含 gorm 软删除的记录；不满足条件、驱动不支持或统计不可用时退回精确计数。
+	CountApprox
+)
+
+type countModeContextKey struct{}
+
+// ContextWithCountMode 为本次 Paginate 指定总数计算方式，未指定时使用 CountExact。
+func ContextWithCountMode(ctx context.Context, mode CountMode) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, countModeContextKey{}, mode)
+}
+
+func countModeFrom(ctx context.Context) CountMode {
+	if ctx == nil {
+		return CountExact
+	}
+	mode, _ := ctx.Value(countModeContextKey{}).(CountMode)
+	return mode
+}
+
+// countTotal 按上下文中的 CountMode 计算分页总数。
+func (s *Service[T]) countTotal(ctx context.Context, query *gorm.DB, filters map[string][]string) (int64, error) {
+	switch countModeFrom(ctx) {
+	case CountSkip:
+		return -1, nil
+	case CountApprox:
+		if len(filters) == 0 && len(s.scopes) == 0 && s.softDeleteColumn == "" {
+			if total, ok := s.approxCount(ctx); ok {
+				return total, nil
+			}
+		}
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	return total, err
+}
+
+// approxCount 读取表的估算行数，驱动不支持或查询失败时返回 false。
+func (s *Service[T]) approxCount(ctx context.Context) (int64, bool) {
+	stmt := &gorm.Statement{DB: s.db}
+	if err := stmt.Parse(new(T)); err != nil || stmt.Table == "" {
+		return 0, false
+	}
+
+	var raw string
+	switch s.db.Dialector.Name() {
+	case "mysql":
+		raw = "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+	case "postgres":
+		raw = "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(?)"
+	default:
+		return 0, false
+	}
+
+	var estimate sql.NullInt64
+	if err := s.db.WithContext(ctx).Raw(raw, stmt.Table).Scan(&estimate).Error; err != nil || !estimate.Valid || estimate.Int64 < 0 {
+		return 0, false
+	}
+	return estimate.Int64, true
+}
@@ -2,30 +2,135 @@ package crud
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
+	"github.com/yinqf/go-pkg/logger"
 	"github.com/yinqf/go-pkg/response"
 	"github.com/yinqf/go-pkg/utils"
 )
 
 // ServiceContract 描述了泛型 CRUD 处理器所依赖的服务能力。
+// 其余接口依赖下方的可选能力接口，服务未实现时对应接口响应 501；*Service 实现了全部能力。
 type ServiceContract[T any] interface {
 	SaveOrUpdate(ctx context.Context, entity *T) error
 	DeleteByID(ctx context.Context, id string) error
 	Paginate(ctx context.Context, page, size int, filters map[string][]string, orders []OrderOption) ([]T, int64, error)
 }
 
+// Finder 为 Get 接口所需的能力。
+type Finder[T any] interface {
+	FindByID(ctx context.Context, id string) (*T, error)
+}
+
+// BatchCreator 为 BatchCreate 接口所需的能力。
+type BatchCreator[T any] interface {
+	BatchCreate(ctx context.Context, entities []T, batchSize int) error
+}
+
+// HardDeleter 为 Delete 接口 hard=true 时所需的能力。
+type HardDeleter interface {
+	HardDeleteByID(ctx context.Context, id string) error
+}
+
+// Restorer 为 Restore 接口所需的能力。
+type Restorer interface {
+	RestoreByID(ctx context.Context, id string) error
+}
+
+// Counter 为 Count 接口所需的能力。
+type Counter interface {
+	Count(ctx context.Context, filters map[string][]string) (int64, error)
+}
+
+// MergePatcher 为 Patch 接口所需的能力。
+type MergePatcher interface {
+	MergePatch(ctx context.Context, id, column string, patch []byte) (json.RawMessage, error)
+}
+
+// GroupCounter 为 GroupCount 接口所需的能力。
+type GroupCounter interface {
+	GroupCount(ctx context.Context, column string, filters map[string][]string) (map[string]int64, error)
+}
+
+// ColumnLister 为 Schema 接口与强制筛选列校验所需的能力。
+type ColumnLister interface {
+	Columns() []ColumnInfo
+}
+
+// ErrForbidden 可由 PreSave 等钩子返回，处理器会以 403 响应。
+var ErrForbidden = errors.New("forbidden")
+
 type Handler[T any] struct {
-	service ServiceContract[T]
+	service        ServiceContract[T]
+	defaultFilters func(c *gin.Context) map[string][]string
+	preSave        func(c *gin.Context, entity *T) error
+	pathFilters    map[string]string
+	patchColumns   map[string]bool
+	allowHard      func(c *gin.Context) bool
 }
 
-func NewHandler[T any](svc ServiceContract[T]) *Handler[T] {
-	return &Handler[T]{service: svc}
+// HandlerOption 用于定制 Handler 的行为。
+type HandlerOption[T any] func(*Handler[T])
+
+// WithDefaultFilters 设置每次请求都会强制附加的筛选条件，同名的客户端查询参数会被覆盖。
+// 条件同样作用于 Get、Delete、Restore 与 Patch，不满足条件的记录按不存在处理，无法通过 id 绕过数据范围。
+func WithDefaultFilters[T any](fn func(c *gin.Context) map[string][]string) HandlerOption[T] {
+	return func(h *Handler[T]) {
+		h.defaultFilters = fn
+	}
+}
+
+// WithPathFilters 将路由路径参数映射为强制筛选列，例如 {"user_id": "user_id"} 用于 /users/:user_id/orders。
+// 与 WithDefaultFilters 相同，客户端无法通过同名查询参数覆盖，且同样作用于按 id 操作的接口。
+func WithPathFilters[T any](paramToColumn map[string]string) HandlerOption[T] {
+	return func(h *Handler[T]) {
+		h.pathFilters = make(map[string]string, len(paramToColumn))
+		for param, column := range paramToColumn {
+			h.pathFilters[param] = column
+		}
+	}
+}
+
+// WithPreSave 设置绑定请求体之后、调用服务保存之前执行的钩子，可注入服务端控制的字段。
+// 钩子返回包装 ErrForbidden 的错误时响应 403，其余错误响应 400。
+func WithPreSave[T any](fn func(c *gin.Context, entity *T) error) HandlerOption[T] {
+	return func(h *Handler[T]) {
+		h.preSave = fn
+	}
+}
+
+// WithMergePatchColumns 指定允许通过 Patch 接口进行 JSON 合并补丁的列，未指定的列返回 400。
+func WithMergePatchColumns[T any](cols ...string) HandlerOption[T] {
+	return func(h *Handler[T]) {
+		h.patchColumns = toColumnSet(cols)
+	}
+}
+
+// WithHardDelete 允许 Delete 接口通过 hard=true 永久删除记录，allow 返回 false 时响应 403，例如仅放行管理员。
+// 未设置时 hard=true 一律响应 403。
+func WithHardDelete[T any](allow func(c *gin.Context) bool) HandlerOption[T] {
+	return func(h *Handler[T]) {
+		h.allowHard = allow
+	}
+}
+
+func NewHandler[T any](svc ServiceContract[T], opts ...HandlerOption[T]) *Handler[T] {
+	h := &Handler[T]{service: svc}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	return h
 }
 
 func (h *Handler[T]) SaveOrUpdate(c *gin.Context) {
@@ -35,14 +140,52 @@ func (h *Handler[T]) SaveOrUpdate(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.SaveOrUpdate(c.Request.Context(), &payload); err != nil {
-		response.Error(c, err.Error())
+	if h.preSave != nil {
+		if err := h.preSave(c, &payload); err != nil {
+			writePreSaveError(c, err)
+			return
+		}
+	}
+
+	if err := h.service.SaveOrUpdate(requestContext(c), &payload); err != nil {
+		writeServiceError(c, err)
 		return
 	}
 
 	response.Success(c, payload)
 }
 
+// BatchCreate 接收 JSON 数组并批量创建，PreSave 钩子会对每个元素执行。
+func (h *Handler[T]) BatchCreate(c *gin.Context) {
+	creator, ok := h.service.(BatchCreator[T])
+	if !ok {
+		writeNotImplemented(c, "BatchCreate")
+		return
+	}
+
+	var payload []T
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		response.ErrorWithStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if h.preSave != nil {
+		for i := range payload {
+			if err := h.preSave(c, &payload[i]); err != nil {
+				writePreSaveError(c, err)
+				return
+			}
+		}
+	}
+
+	if err := creator.BatchCreate(requestContext(c), payload, 0); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"list": payload, "total": len(payload)})
+}
+
 func (h *Handler[T]) List(c *gin.Context) {
 	page, size, err := utils.ParsePageAndSize(c)
 	if err != nil {
@@ -52,9 +195,32 @@ func (h *Handler[T]) List(c *gin.Context) {
 
 	rawQuery := c.Request.URL.Query()
 	orders := ParseOrderOptions(rawQuery)
+	filters, ok := h.queryFilters(c)
+	if !ok {
+		return
+	}
+
+	result, svcErr := utils.PageResult[T]{Page: page, Size: size}.Fill(h.service.Paginate(requestContext(c), page, size, filters, orders))
+	if svcErr != nil {
+		writeServiceError(c, svcErr)
+		return
+	}
+
+	// 空结果由 ToResponse 输出 [] 而非 null，避免前端表格组件按对象处理。
+	response.Success(c, result.ToResponse())
+}
+
+// reservedQueryKeys 为分页与排序参数，不参与筛选。
+var reservedQueryKeys = map[string]bool{
+	"page": true, "size": true, "order": true, "sort": true, "order_by": true, "orderBy": true,
+}
+
+// queryFilters 从查询参数中提取筛选条件并合并强制筛选，失败时已写入响应并返回 false。
+func (h *Handler[T]) queryFilters(c *gin.Context, reserved ...string) (map[string][]string, bool) {
+	rawQuery := c.Request.URL.Query()
 	filters := make(map[string][]string, len(rawQuery))
 	for key, values := range rawQuery {
-		if key == "page" || key == "size" || key == "order" || key == "sort" || key == "order_by" || key == "orderBy" {
+		if reservedQueryKeys[key] || containsString(reserved, key) {
 			continue
 		}
 		cleaned := make([]string, 0, len(values))
@@ -67,19 +233,99 @@ func (h *Handler[T]) List(c *gin.Context) {
 			filters[key] = cleaned
 		}
 	}
+	if status, err := h.applyDefaultFilters(c, filters); err != nil {
+		response.ErrorWithStatus(c, status, err.Error())
+		return nil, false
+	}
+	return filters, true
+}
 
-	items, total, svcErr := h.service.Paginate(c.Request.Context(), page, size, filters, orders)
-	if svcErr != nil {
-		response.Error(c, svcErr.Error())
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDefaultFilters 合并强制筛选条件（默认筛选与路径参数）。
+// 强制条件缺少有效值时返回 403，列不在筛选白名单内时返回 500，避免条件被静默忽略而放开数据范围。
+func (h *Handler[T]) applyDefaultFilters(c *gin.Context, filters map[string][]string) (int, error) {
+	mandatory := make(map[string][]string, len(h.pathFilters))
+	if h.defaultFilters != nil {
+		for key, values := range h.defaultFilters(c) {
+			mandatory[key] = values
+		}
+	}
+	for param, column := range h.pathFilters {
+		mandatory[column] = []string{c.Param(param)}
+	}
+	if len(mandatory) == 0 {
+		return 0, nil
+	}
+
+	lister, ok := h.service.(ColumnLister)
+	if !ok {
+		return http.StatusInternalServerError, errors.New("mandatory filters require a service that implements ColumnLister")
+	}
+	filterable := make(map[string]bool)
+	for _, col := range lister.Columns() {
+		filterable[col.Name] = col.Filterable
+	}
+
+	for key, values := range mandatory {
+		if len(normalizeFilterValues(values)) == 0 {
+			return http.StatusForbidden, fmt.Errorf("mandatory filter %s is empty", key)
+		}
+		if column, _ := parseFilterKey(key); !filterable[column] {
+			return http.StatusInternalServerError, fmt.Errorf("mandatory filter column %s is not filterable", column)
+		}
+		filters[key] = values
+	}
+	return 0, nil
+}
+
+// scopedContext 返回附加了强制筛选条件的请求上下文，使按 id 操作的接口与 List 遵守相同的数据范围；
+// 失败时已写入响应并返回 false。
+func (h *Handler[T]) scopedContext(c *gin.Context) (context.Context, bool) {
+	filters := make(map[string][]string)
+	if status, err := h.applyDefaultFilters(c, filters); err != nil {
+		response.ErrorWithStatus(c, status, err.Error())
+		return nil, false
+	}
+
+	ctx := requestContext(c)
+	if len(filters) > 0 {
+		ctx = ContextWithScopeFilters(ctx, filters)
+	}
+	return ctx, true
+}
+
+func (h *Handler[T]) Get(c *gin.Context) {
+	finder, ok := h.service.(Finder[T])
+	if !ok {
+		writeNotImplemented(c, "FindByID")
 		return
 	}
 
-	response.Success(c, gin.H{
-		"list":  items,
-		"page":  page,
-		"size":  size,
-		"total": total,
-	})
+	id := c.Query("id")
+	if id == "" {
+		response.ErrorWithStatus(c, http.StatusBadRequest, "id is required")
+		return
+	}
+	ctx, ok := h.scopedContext(c)
+	if !ok {
+		return
+	}
+
+	entity, err := finder.FindByID(ctx, id)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
 }
 
 func (h *Handler[T]) Delete(c *gin.Context) {
@@ -89,15 +335,196 @@ func (h *Handler[T]) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteByID(c.Request.Context(), id); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			response.ErrorWithStatus(c, http.StatusNotFound, "记录不存在")
+	ctx, ok := h.scopedContext(c)
+	if !ok {
+		return
+	}
+
+	deleteByID := h.service.DeleteByID
+	if hard, _ := strconv.ParseBool(c.Query("hard")); hard {
+		if h.allowHard == nil || !h.allowHard(c) {
+			response.ErrorWithStatus(c, http.StatusForbidden, "hard delete is not allowed")
+			return
+		}
+		deleter, ok := h.service.(HardDeleter)
+		if !ok {
+			writeNotImplemented(c, "HardDeleteByID")
 			return
 		}
+		deleteByID = deleter.HardDeleteByID
+	}
 
-		response.Error(c, err.Error())
+	if err := deleteByID(ctx, id); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"id": id})
+}
+
+func (h *Handler[T]) Restore(c *gin.Context) {
+	restorer, ok := h.service.(Restorer)
+	if !ok {
+		writeNotImplemented(c, "RestoreByID")
+		return
+	}
+
+	id := c.Query("id")
+	if id == "" {
+		response.ErrorWithStatus(c, http.StatusBadRequest, "id is required")
+		return
+	}
+	ctx, ok := h.scopedContext(c)
+	if !ok {
+		return
+	}
+
+	if err := restorer.RestoreByID(ctx, id); err != nil {
+		writeServiceError(c, err)
 		return
 	}
 
 	response.Success(c, gin.H{"id": id})
 }
+
+// Patch 按 RFC 7386 对 column 参数指定的 JSON 列应用请求体中的合并补丁，返回合并后的文档。
+func (h *Handler[T]) Patch(c *gin.Context) {
+	patcher, ok := h.service.(MergePatcher)
+	if !ok {
+		writeNotImplemented(c, "MergePatch")
+		return
+	}
+
+	id := c.Query("id")
+	if id == "" {
+		response.ErrorWithStatus(c, http.StatusBadRequest, "id is required")
+		return
+	}
+	column := strings.TrimSpace(c.Query("column"))
+	if !h.patchColumns[column] {
+		response.ErrorWithStatus(c, http.StatusBadRequest, fmt.Sprintf("column %q does not support merge patch", column))
+		return
+	}
+
+	ctx, ok := h.scopedContext(c)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		response.ErrorWithStatus(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merged, err := patcher.MergePatch(ctx, id, column, patch)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"id": id, column: merged})
+}
+
+// CountHandler 仅返回满足查询参数筛选条件的总数，不查询列表数据。
+func (h *Handler[T]) CountHandler(c *gin.Context) {
+	counter, ok := h.service.(Counter)
+	if !ok {
+		writeNotImplemented(c, "Count")
+		return
+	}
+
+	filters, ok := h.queryFilters(c)
+	if !ok {
+		return
+	}
+
+	total, err := counter.Count(requestContext(c), filters)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"total": total})
+}
+
+// GroupCount 按 by 参数指定的列分组计数，其余查询参数作为筛选条件。
+func (h *Handler[T]) GroupCount(c *gin.Context) {
+	counter, ok := h.service.(GroupCounter)
+	if !ok {
+		writeNotImplemented(c, "GroupCount")
+		return
+	}
+
+	column := strings.TrimSpace(c.Query("by"))
+	if column == "" {
+		response.ErrorWithStatus(c, http.StatusBadRequest, "by is required")
+		return
+	}
+
+	filters, ok := h.queryFilters(c, "by")
+	if !ok {
+		return
+	}
+
+	counts, err := counter.GroupCount(requestContext(c), column, filters)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, counts)
+}
+
+// Schema 返回实体的列元数据，便于前端动态渲染。
+func (h *Handler[T]) Schema(c *gin.Context) {
+	lister, ok := h.service.(ColumnLister)
+	if !ok {
+		writeNotImplemented(c, "Columns")
+		return
+	}
+	response.Success(c, gin.H{"columns": lister.Columns()})
+}
+
+// writeNotImplemented 在服务未实现接口所需的可选能力时响应 501。
+func writeNotImplemented(c *gin.Context, method string) {
+	response.ErrorWithStatus(c, http.StatusNotImplemented, fmt.Sprintf("service does not implement %s", method))
+}
+
+// writePreSaveError 将 PreSave 钩子的错误映射为 403 或 400。
+func writePreSaveError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrForbidden) {
+		status = http.StatusForbidden
+	}
+	response.ErrorWithStatus(c, status, err.Error())
+}
+
+// requestContext 返回请求上下文，并将 X-Request-ID 写入其中，使 Service 发出的 SQL 日志可关联到原始请求。
+// 请求头与 response.RequestID 中间件使用同样的校验，不合法时忽略。
+func requestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if _, ok := logger.RequestIDFromContext(ctx); ok {
+		return ctx
+	}
+	if requestID := c.GetHeader(logger.RequestIDHeader); response.ValidRequestID(requestID) {
+		return logger.ContextWithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+// writeServiceError 将服务层错误映射为对应的 HTTP 状态码。
+func writeServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		response.ErrorWithStatus(c, http.StatusNotFound, "记录不存在")
+	case errors.Is(err, ErrInvalidFilter), errors.Is(err, ErrInvalidID), errors.Is(err, ErrInvalidColumn),
+		errors.Is(err, ErrTooManyFilters), errors.Is(err, ErrTooManyOrders), errors.Is(err, ErrInvalidCursor),
+		errors.Is(err, ErrInvalidCollation), errors.Is(err, ErrInvalidPatch):
+		response.ErrorWithStatus(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrCircuitOpen):
+		response.ErrorWithStatus(c, http.StatusServiceUnavailable, err.Error())
+	default:
+		response.Error(c, err.Error())
+	}
+}
@@ -0,0 +1,111 @@
+package crud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	// 测试中不创建 logs 目录，日志仅输出到标准输出。
+	_ = os.Setenv("LOG_STDOUT_ONLY", "true")
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+type userOrder struct {
+	ID        uint
+	UserID    uint
+	Note      string
+	DeletedAt gorm.DeletedAt
+}
+
+func TestPathFiltersScopeIDEndpoints(t *testing.T) {
+	db := newTestDB(t, &userOrder{})
+	orders := []userOrder{{ID: 1, UserID: 1, Note: "mine"}, {ID: 2, UserID: 2, Note: "theirs"}}
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	h := NewHandler[userOrder](NewService[userOrder](db), WithPathFilters[userOrder](map[string]string{"user_id": "user_id"}))
+	router := gin.New()
+	router.GET("/users/:user_id/orders/get", h.Get)
+	router.DELETE("/users/:user_id/orders", h.Delete)
+
+	for _, tc := range []struct {
+		method string
+		target string
+		want   int
+	}{
+		{http.MethodGet, "/users/1/orders/get?id=1", http.StatusOK},
+		{http.MethodGet, "/users/1/orders/get?id=2", http.StatusNotFound},
+		{http.MethodDelete, "/users/1/orders?id=2", http.StatusNotFound},
+		{http.MethodDelete, "/users/2/orders?id=2", http.StatusOK},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+		if rec.Code != tc.want {
+			t.Errorf("%s %s: status = %d, want %d (%s)", tc.method, tc.target, rec.Code, tc.want, rec.Body.String())
+		}
+	}
+
+	var remaining []userOrder
+	if err := db.Find(&remaining).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != 1 {
+		t.Fatalf("remaining = %+v, want only order 1", remaining)
+	}
+}
+
+// *Service 需实现 Handler 使用的全部能力接口。
+var (
+	_ ServiceContract[userOrder] = (*Service[userOrder])(nil)
+	_ Finder[userOrder]          = (*Service[userOrder])(nil)
+	_ BatchCreator[userOrder]    = (*Service[userOrder])(nil)
+	_ HardDeleter                = (*Service[userOrder])(nil)
+	_ Restorer                   = (*Service[userOrder])(nil)
+	_ Counter                    = (*Service[userOrder])(nil)
+	_ MergePatcher               = (*Service[userOrder])(nil)
+	_ GroupCounter               = (*Service[userOrder])(nil)
+	_ ColumnLister               = (*Service[userOrder])(nil)
+)
+
+// minimalService 仅实现 ServiceContract 的三个方法。
+type minimalService struct{}
+
+func (minimalService) SaveOrUpdate(context.Context, *userOrder) error { return nil }
+func (minimalService) DeleteByID(context.Context, string) error       { return nil }
+func (minimalService) Paginate(context.Context, int, int, map[string][]string, []OrderOption) ([]userOrder, int64, error) {
+	return nil, 0, nil
+}
+
+func TestHandlerOptionalCapabilities(t *testing.T) {
+	h := NewHandler[userOrder](minimalService{})
+	router := gin.New()
+	router.GET("/orders", h.List)
+	router.GET("/orders/get", h.Get)
+	router.GET("/orders/count", h.CountHandler)
+	router.GET("/orders/schema", h.Schema)
+
+	for _, tc := range []struct {
+		target string
+		want   int
+	}{
+		{"/orders", http.StatusOK},
+		{"/orders/get?id=1", http.StatusNotImplemented},
+		{"/orders/count", http.StatusNotImplemented},
+		{"/orders/schema", http.StatusNotImplemented},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+		if rec.Code != tc.want {
+			t.Errorf("GET %s: status = %d, want %d (%s)", tc.target, rec.Code, tc.want, rec.Body.String())
+		}
+	}
+}
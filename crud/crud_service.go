@@ -3,36 +3,184 @@ package crud
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // Service 用于封装带主键实体的通用增删改查能力。
 type Service[T any] struct {
-	db *gorm.DB
+	db         *gorm.DB
+	sortable   map[string]bool
+	filterable map[string]bool
+	scopes     []func(*gorm.DB) *gorm.DB
+
+	maxPageSize int
+	maxFilters  int
+	maxOrders   int
+	afterCommit AfterCommitFunc
+	breaker     *circuitBreaker
+
+	softDeleteColumn string
+	softDeleteValue  interface{}
+	indexHints       map[string]bool
+	collations       map[string]bool
+	selectColumns    []string
+
+	// pending 非空表示 Service 绑定在 WithTx 开启的事务上，提交回调延迟到事务提交后触发。
+	pending *[]pendingCommit
 }
 
-func NewService[T any](db *gorm.DB) *Service[T] {
-	return &Service[T]{db: db}
+const (
+	defaultMaxPageSize = 100
+	defaultMaxFilters  = 20
+	defaultMaxOrders   = 5
+)
+
+var (
+	// ErrTooManyFilters 表示单次请求的筛选条件数量超过上限。
+	ErrTooManyFilters = errors.New("too many filters")
+	// ErrTooManyOrders 表示单次请求的排序列数量超过上限。
+	ErrTooManyOrders = errors.New("too many order columns")
+)
+
+// ServiceOption 用于定制 Service 的行为。
+type ServiceOption[T any] func(*Service[T])
+
+// WithFilterableColumns 限定允许筛选的列，与 schema 列取交集；排序、分组与聚合同样受此限制。未设置时沿用全部 schema 列。
+func WithFilterableColumns[T any](cols ...string) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.filterable = toColumnSet(cols)
+	}
+}
+
+// WithSortableColumns 限定允许排序的列，未设置时沿用可筛选列；筛选不受此限制。
+func WithSortableColumns[T any](cols ...string) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.sortable = toColumnSet(cols)
+	}
+}
+
+// WithSelect 限定 Paginate 与 Top 查询的列，用于列表页跳过大字段；不是实体真实列的名称会被忽略，全部无效或未设置时查询全部列。
+// 未选择的字段在返回的实体中为零值，列表之外的接口（如 FindByID）不受影响。
+func WithSelect[T any](cols ...string) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.selectColumns = s.selectColumns[:0]
+		seen := make(map[string]bool, len(cols))
+		for _, col := range cols {
+			if trimmed := strings.TrimSpace(col); trimmed != "" && !seen[trimmed] {
+				seen[trimmed] = true
+				s.selectColumns = append(s.selectColumns, trimmed)
+			}
+		}
+	}
+}
+
+// WithScopes 设置所有查询与写操作都会附加的强制作用域，例如租户隔离条件。
+func WithScopes[T any](scopes ...func(*gorm.DB) *gorm.DB) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.scopes = append(s.scopes, scopes...)
+	}
+}
+
+// WithMaxPageSize 设置单次查询允许返回的最大行数，默认为 100；Paginate、Top 与 PaginateCursor 的 size 超过上限时按上限截断。
+func WithMaxPageSize[T any](size int) ServiceOption[T] {
+	return func(s *Service[T]) {
+		if size > 0 {
+			s.maxPageSize = size
+		}
+	}
+}
+
+// WithMaxFilters 设置单次查询允许的筛选条件数量，默认为 20；__or 分组的每一项与 like 筛选的每个值各计为一个条件。
+func WithMaxFilters[T any](n int) ServiceOption[T] {
+	return func(s *Service[T]) {
+		if n > 0 {
+			s.maxFilters = n
+		}
+	}
+}
+
+// WithMaxOrders 设置单次查询允许的排序列数量，默认为 5。
+func WithMaxOrders[T any](n int) ServiceOption[T] {
+	return func(s *Service[T]) {
+		if n > 0 {
+			s.maxOrders = n
+		}
+	}
+}
+
+func NewService[T any](db *gorm.DB, opts ...ServiceOption[T]) *Service[T] {
+	s := &Service[T]{
+		db:          db,
+		maxPageSize: defaultMaxPageSize,
+		maxFilters:  defaultMaxFilters,
+		maxOrders:   defaultMaxOrders,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// session 返回绑定上下文并附加强制作用域的会话；配置了自定义软删除列时同时排除已删除的记录。
+func (s *Service[T]) session(ctx context.Context) *gorm.DB {
+	session := s.db.WithContext(ctx)
+	if len(s.scopes) > 0 {
+		session = session.Scopes(s.scopes...)
+	}
+	if s.softDeleteColumn != "" {
+		session = session.Scopes(s.excludeSoftDeleted)
+	}
+	return session
+}
+
+// DB 返回绑定上下文与模型 T 的 gorm 句柄，用于通用 CRUD 无法表达的自定义查询。
+// 通过该句柄的写操作不经过 SaveOrUpdate 的零值判断、熔断与提交钩子，也不附加强制作用域；一般应优先使用 Session。
+func (s *Service[T]) DB(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Model(new(T))
+}
+
+// Session 返回绑定上下文与模型 T、并已附加强制作用域（如租户隔离）与自定义软删除排除条件的 gorm 句柄，
+// 是自定义查询的首选入口，保证原生查询同样遵守 Service 的隔离约束。
+func (s *Service[T]) Session(ctx context.Context) *gorm.DB {
+	return s.session(ctx).Model(new(T))
+}
+
+// parseSchema 解析实体 T 的 schema。
+func (s *Service[T]) parseSchema() (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: s.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	if stmt.Schema == nil {
+		return nil, errors.New("failed to parse schema")
+	}
+	return stmt.Schema, nil
 }
 
 // OrderOption 描述单个排序条件。
 type OrderOption struct {
 	Column string
 	Desc   bool
+	// Collation 为可选的排序规则，必须在 WithCollations 配置的白名单内。
+	Collation string
 }
 
+// SaveOrUpdate 主键为空时创建记录，否则按主键更新非零值字段；待更新的记录不存在时返回 gorm.ErrRecordNotFound。
 func (s *Service[T]) SaveOrUpdate(ctx context.Context, entity *T) error {
 	if entity == nil {
 		return errors.New("entity is nil")
 	}
 
-	session := s.db.WithContext(ctx)
+	session := s.session(ctx)
 	stmt := &gorm.Statement{DB: session, Context: ctx}
 	if err := stmt.Parse(entity); err != nil {
 		return err
@@ -56,7 +204,11 @@ func (s *Service[T]) SaveOrUpdate(ctx context.Context, entity *T) error {
 
 	_, zeroPK := primary.ValueOf(ctx, elem)
 	if zeroPK {
-		return session.Create(entity).Error
+		if err := s.guard(func() error { return session.Create(entity).Error }); err != nil {
+			return err
+		}
+		s.notifyCommit(ctx, OpCreate, entity)
+		return nil
 	}
 
 	columns := make([]string, 0, len(schema.Fields))
@@ -81,7 +233,44 @@ func (s *Service[T]) SaveOrUpdate(ctx context.Context, entity *T) error {
 		return nil
 	}
 
-	return session.Model(entity).Select(columns).Updates(entity).Error
+	err := s.guard(func() error {
+		result := session.Model(entity).Select(columns).Updates(entity)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		// MySQL 对值未变化的行同样返回 0，需再按主键确认记录是否存在。
+		key, _ := primary.ValueOf(ctx, elem)
+		var count int64
+		if err := session.Model(new(T)).Where(clause.Eq{Column: clause.Column{Name: primary.DBName}, Value: key}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.notifyCommit(ctx, OpUpdate, entity)
+	return nil
+}
+
+// FindByID 按主键查询单条记录，联合主键使用 tenant_id:1,id:42 格式；未找到时返回 gorm.ErrRecordNotFound，已软删除的记录视为不存在。
+func (s *Service[T]) FindByID(ctx context.Context, id string) (*T, error) {
+	condition, err := s.idCondition(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := new(T)
+	err = s.guard(func() error {
+		return s.applyScopeFilters(ctx, s.session(ctx)).Where(condition).Take(entity).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
 func (s *Service[T]) DeleteByID(ctx context.Context, id string) error {
@@ -89,25 +278,76 @@ func (s *Service[T]) DeleteByID(ctx context.Context, id string) error {
 		return errors.New("id is required")
 	}
 
-	session := s.db.WithContext(ctx)
-	var result *gorm.DB
+	condition, err := s.idCondition(id)
+	if err != nil {
+		return err
+	}
+	return s.deleteWhere(ctx, condition, id)
+}
+
+// HardDeleteByID 绕过软删除永久删除记录（包括已软删除的记录），强制作用域仍然生效；未匹配到记录时返回 gorm.ErrRecordNotFound。
+// 仅用于管理类场景，常规删除应使用 DeleteByID。
+func (s *Service[T]) HardDeleteByID(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("id is required")
+	}
 
-	if numericID, err := strconv.ParseUint(id, 10, 64); err == nil {
-		result = session.Delete(new(T), numericID)
-	} else {
-		result = session.Where("id = ?", id).Delete(new(T))
+	condition, err := s.idCondition(id)
+	if err != nil {
+		return err
 	}
-	if result.Error != nil {
-		return result.Error
+
+	session := s.db.WithContext(ctx)
+	if len(s.scopes) > 0 {
+		session = session.Scopes(s.scopes...)
+	}
+	err = s.guard(func() error {
+		result := s.applyScopeFilters(ctx, session.Unscoped()).Where(condition).Delete(new(T))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	s.notifyCommit(ctx, OpDelete, id)
+	return nil
+}
+
+// deleteWhere 按条件删除记录（配置了自定义软删除列时改为更新该列），未匹配到记录时返回 gorm.ErrRecordNotFound。
+func (s *Service[T]) deleteWhere(ctx context.Context, condition clause.Expression, target interface{}) error {
+	session := s.applyScopeFilters(ctx, s.session(ctx))
+	err := s.guard(func() error {
+		var result *gorm.DB
+		if s.softDeleteColumn != "" {
+			result = session.Model(new(T)).Where(condition).Update(s.softDeleteColumn, s.softDeleteValue)
+		} else {
+			result = session.Where(condition).Delete(new(T))
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	s.notifyCommit(ctx, OpDelete, target)
 	return nil
 }
 
+// Paginate 按筛选与排序条件分页查询，同时返回总数。总数默认精确计算，对大表可通过 ContextWithCountMode
+// 跳过（total 为 -1）或改用数据库统计的估算值，以牺牲总数准确性换取更低的查询延迟。
 func (s *Service[T]) Paginate(ctx context.Context, page, size int, filters map[string][]string, orders []OrderOption) ([]T, int64, error) {
 	if page < 1 {
 		page = 1
@@ -116,39 +356,143 @@ func (s *Service[T]) Paginate(ctx context.Context, page, size int, filters map[s
 	if size <= 0 {
 		size = 10
 	}
+	if size > s.maxPageSize {
+		size = s.maxPageSize
+	}
 
 	offset := (page - 1) * size
 
+	if err := s.checkLimits(filters, orders); err != nil {
+		return nil, 0, err
+	}
+
 	var (
 		list  []T
 		total int64
 	)
 
-	session := s.db.WithContext(ctx)
-	model := new(T)
+	query, allowed := s.listQuery(ctx, filters)
 
-	query := session.Model(model)
-	allowed := columnAllowlist(query, model)
-	query = ApplyFilters(query, filters, allowed)
+	err := s.guard(func() error {
+		var err error
+		if total, err = s.countTotal(ctx, query, filters); err != nil {
+			return err
+		}
 
-	if err := query.Count(&total).Error; err != nil {
+		query = s.applySelect(s.applyOrders(query, orders, allowed))
+		return query.Limit(size).Offset(offset).Find(&list).Error
+	})
+	if err != nil {
 		return nil, 0, err
 	}
 
-	orderBy := sanitizeOrders(orders, allowed)
-	if len(orderBy) == 0 {
-		query = query.Order("id")
-	} else {
-		for _, opt := range orderBy {
-			query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: opt.Column}, Desc: opt.Desc})
+	return list, total, nil
+}
+
+// Top 返回按筛选与排序条件取出的前 n 条记录，不执行 COUNT，n 超过最大分页大小时按上限截断。
+func (s *Service[T]) Top(ctx context.Context, n int, filters map[string][]string, orders []OrderOption) ([]T, error) {
+	if n <= 0 {
+		return nil, errors.New("n must be positive")
+	}
+	if n > s.maxPageSize {
+		n = s.maxPageSize
+	}
+	if err := s.checkLimits(filters, orders); err != nil {
+		return nil, err
+	}
+
+	query, allowed := s.listQuery(ctx, filters)
+	query = s.applySelect(s.applyOrders(query, orders, allowed))
+
+	var list []T
+	if err := s.guard(func() error { return query.Limit(n).Find(&list).Error }); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Count 返回满足筛选条件的记录数，筛选规则与 Paginate 一致。
+func (s *Service[T]) Count(ctx context.Context, filters map[string][]string) (int64, error) {
+	if err := s.checkLimits(filters, nil); err != nil {
+		return 0, err
+	}
+
+	query, _ := s.listQuery(ctx, filters)
+	var total int64
+	if err := s.guard(func() error { return query.Count(&total).Error }); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// checkLimits 校验筛选条件与排序列数量，防止构造过于复杂的查询；同时校验排序规则白名单。
+func (s *Service[T]) checkLimits(filters map[string][]string, orders []OrderOption) error {
+	if terms := countFilterTerms(filters); terms > s.maxFilters {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyFilters, terms, s.maxFilters)
+	}
+	if len(orders) > s.maxOrders {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyOrders, len(orders), s.maxOrders)
+	}
+	for _, opt := range orders {
+		if err := s.checkCollation(opt.Collation); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if err := query.Limit(size).Offset(offset).Find(&list).Error; err != nil {
-		return nil, 0, err
+// countFilterTerms 统计筛选生成的条件数：OR 分组的每一项与 like 的每个值各生成一个条件，其余每个键计为一个。
+func countFilterTerms(filters map[string][]string) int {
+	terms := 0
+	for key, values := range filters {
+		_, op := parseFilterKey(key)
+		if key == OrFilterKey || op == filterLike {
+			terms += len(normalizeFilterValues(values))
+			continue
+		}
+		terms++
+	}
+	return terms
+}
+
+// listQuery 构建附加筛选条件的列表查询，并返回列白名单。
+func (s *Service[T]) listQuery(ctx context.Context, filters map[string][]string) (*gorm.DB, map[string]bool) {
+	model := new(T)
+	query := s.session(ctx).Model(model)
+	allowed := s.filterableColumns(columnAllowlist(query, model))
+	query = s.applyIndexHint(ctx, query)
+	return ApplyFilters(query, filters, allowed), allowed
+}
+
+// applyOrders 追加排序条件，未指定有效排序时按 id 排序。
+func (s *Service[T]) applyOrders(query *gorm.DB, orders []OrderOption, allowed map[string]bool) *gorm.DB {
+	orderBy := sanitizeOrders(orders, s.sortableColumns(allowed))
+	if len(orderBy) == 0 {
+		return query.Order("id")
 	}
+	for _, opt := range orderBy {
+		query = s.orderBy(query, opt)
+	}
+	return query
+}
 
-	return list, total, nil
+// applySelect 按 WithSelect 限定查询列，仅保留实体的真实列。
+func (s *Service[T]) applySelect(query *gorm.DB) *gorm.DB {
+	if len(s.selectColumns) == 0 {
+		return query
+	}
+
+	columns := columnAllowlist(query, new(T))
+	selected := make([]string, 0, len(s.selectColumns))
+	for _, col := range s.selectColumns {
+		if columns[col] {
+			selected = append(selected, col)
+		}
+	}
+	if len(selected) == 0 {
+		return query
+	}
+	return query.Select(selected)
 }
 
 type filterOp string
@@ -169,12 +513,20 @@ const (
 )
 
 // ApplyFilters 根据通用筛选语法构建查询条件。
+// 筛选值会按字段类型转换，无法转换时通过 query.AddError 记录 ErrInvalidFilter。
 func ApplyFilters(query *gorm.DB, filters map[string][]string, allowed map[string]bool) *gorm.DB {
 	if query == nil || len(filters) == 0 {
 		return query
 	}
 
 	for key, vals := range filters {
+		if key == OrFilterKey {
+			if query = applyOrGroup(query, vals, allowed); query.Error != nil {
+				return query
+			}
+			continue
+		}
+
 		column, op := parseFilterKey(key)
 		if column == "" || !allowed[column] {
 			continue
@@ -185,47 +537,60 @@ func ApplyFilters(query *gorm.DB, filters map[string][]string, allowed map[strin
 			continue
 		}
 
+		field := lookupFilterField(query, column)
 		columnExpr := clause.Column{Name: column}
 		switch op {
 		case filterEq:
-			query = query.Where(clause.Eq{Column: columnExpr, Value: values[0]})
-		case filterNe:
-			query = query.Where(clause.Neq{Column: columnExpr, Value: values[0]})
-		case filterGt:
-			query = query.Where(clause.Gt{Column: columnExpr, Value: values[0]})
-		case filterGte:
-			query = query.Where(clause.Gte{Column: columnExpr, Value: values[0]})
-		case filterLt:
-			query = query.Where(clause.Lt{Column: columnExpr, Value: values[0]})
-		case filterLte:
-			query = query.Where(clause.Lte{Column: columnExpr, Value: values[0]})
+			// 仅重复参数转为 IN，值内的逗号按字面量匹配；逗号分隔请使用 __in。
+			list, err := coerceFilterValues(field, values)
+			if err != nil {
+				_ = query.AddError(err)
+				return query
+			}
+			switch len(list) {
+			case 0:
+				continue
+			case 1:
+				query = query.Where(clause.Eq{Column: columnExpr, Value: list[0]})
+			default:
+				query = query.Where(clause.IN{Column: columnExpr, Values: list})
+			}
+		case filterNe, filterGt, filterGte, filterLt, filterLte:
+			value, err := coerceFilterValue(field, values[0])
+			if err != nil {
+				_ = query.AddError(err)
+				return query
+			}
+			query = query.Where(comparisonExpr(op, columnExpr, value))
 		case filterLike:
-			value := values[0]
-			if value != "" && !strings.ContainsAny(value, "%_") {
-				value = "%" + value + "%"
+			for _, value := range values {
+				pattern := "%" + escapeLikeValue(value) + "%"
+				query = query.Where(clause.Expr{SQL: "? LIKE ? ESCAPE ?", Vars: []interface{}{columnExpr, pattern, likeEscapeChar}})
 			}
-			if value != "" {
-				query = query.Where(clause.Expr{SQL: "? LIKE ?", Vars: []interface{}{columnExpr, value}})
+		case filterIn, filterNotIn:
+			list, err := coerceFilterValues(field, splitCommaValues(values))
+			if err != nil {
+				_ = query.AddError(err)
+				return query
 			}
-		case filterIn:
-			list := splitCommaValues(values)
-			if len(list) > 0 {
-				query = query.Where(clause.IN{Column: columnExpr, Values: toInterfaceSlice(list)})
+			if len(list) == 0 {
+				continue
 			}
-		case filterNotIn:
-			list := splitCommaValues(values)
-			if len(list) > 0 {
-				query = query.Not(clause.IN{Column: columnExpr, Values: toInterfaceSlice(list)})
+			if op == filterIn {
+				query = query.Where(clause.IN{Column: columnExpr, Values: list})
+			} else {
+				query = query.Not(clause.IN{Column: columnExpr, Values: list})
 			}
 		case filterBetween:
 			parts := splitCommaValues(values)
 			if len(parts) >= 2 {
-				start := strings.TrimSpace(parts[0])
-				end := strings.TrimSpace(parts[1])
-				if start != "" && end != "" {
-					query = query.Where(clause.Gte{Column: columnExpr, Value: start}).
-						Where(clause.Lte{Column: columnExpr, Value: end})
+				bounds, err := coerceFilterValues(field, parts[:2])
+				if err != nil {
+					_ = query.AddError(err)
+					return query
 				}
+				query = query.Where(clause.Gte{Column: columnExpr, Value: bounds[0]}).
+					Where(clause.Lte{Column: columnExpr, Value: bounds[1]})
 			}
 		case filterIsNull:
 			if isNull, ok := parseBoolValue(firstValue(values)); !ok || isNull {
@@ -241,6 +606,34 @@ func ApplyFilters(query *gorm.DB, filters map[string][]string, allowed map[strin
 	return query
 }
 
+// likeEscapeChar 为 LIKE 的转义字符。SQLite 没有默认转义字符，MySQL 在 NO_BACKSLASH_ESCAPES 模式下不识别反斜杠，
+// 因此显式生成 ESCAPE；以参数绑定传入，避免字面量 '\' 在不同 SQL 模式下含义不同。
+const likeEscapeChar = `\`
+
+// likeEscaper 转义 LIKE 通配符，使用户输入按字面量匹配。
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLikeValue(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+func comparisonExpr(op filterOp, column clause.Column, value interface{}) clause.Expression {
+	switch op {
+	case filterNe:
+		return clause.Neq{Column: column, Value: value}
+	case filterGt:
+		return clause.Gt{Column: column, Value: value}
+	case filterGte:
+		return clause.Gte{Column: column, Value: value}
+	case filterLt:
+		return clause.Lt{Column: column, Value: value}
+	case filterLte:
+		return clause.Lte{Column: column, Value: value}
+	default:
+		return clause.Eq{Column: column, Value: value}
+	}
+}
+
 func parseFilterKey(key string) (string, filterOp) {
 	trimmed := strings.TrimSpace(key)
 	if trimmed == "" {
@@ -336,14 +729,6 @@ func splitCommaValues(vals []string) []string {
 	return result
 }
 
-func toInterfaceSlice(vals []string) []interface{} {
-	result := make([]interface{}, 0, len(vals))
-	for _, val := range vals {
-		result = append(result, val)
-	}
-	return result
-}
-
 func parseBoolValue(raw string) (bool, bool) {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "1", "true", "yes", "y", "on":
@@ -377,12 +762,50 @@ func sanitizeOrders(orders []OrderOption, allowed map[string]bool) []OrderOption
 		if _, ok := seen[column]; ok {
 			continue
 		}
-		result = append(result, OrderOption{Column: column, Desc: opt.Desc})
+		result = append(result, OrderOption{Column: column, Desc: opt.Desc, Collation: strings.TrimSpace(opt.Collation)})
 		seen[column] = struct{}{}
 	}
 	return result
 }
 
+// filterableColumns 在配置了 WithFilterableColumns 时返回与 allowed 的交集。
+func (s *Service[T]) filterableColumns(allowed map[string]bool) map[string]bool {
+	if s.filterable == nil {
+		return allowed
+	}
+	result := make(map[string]bool, len(s.filterable))
+	for column := range s.filterable {
+		if allowed[column] {
+			result[column] = true
+		}
+	}
+	return result
+}
+
+// sortableColumns 返回可排序列：配置了排序白名单时取其与 schema 列的交集。
+func (s *Service[T]) sortableColumns(allowed map[string]bool) map[string]bool {
+	if s.sortable == nil {
+		return allowed
+	}
+	result := make(map[string]bool, len(s.sortable))
+	for column := range s.sortable {
+		if allowed[column] {
+			result[column] = true
+		}
+	}
+	return result
+}
+
+func toColumnSet(cols []string) map[string]bool {
+	set := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		if trimmed := strings.TrimSpace(col); trimmed != "" {
+			set[trimmed] = true
+		}
+	}
+	return set
+}
+
 var columnNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 
 func columnAllowlist(tx *gorm.DB, model interface{}) map[string]bool {
@@ -0,0 +1,182 @@
+package crud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidCursor 表示游标无法解码或与当前排序不匹配。
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorPayload 为游标的编码内容：Key 为最后一行的主键，Value 为排序列的值（仅按非主键列排序时存在）。
+type cursorPayload struct {
+	Column string          `json:"c,omitempty"`
+	Value  json.RawMessage `json:"v,omitempty"`
+	Key    json.RawMessage `json:"k"`
+}
+
+// PaginateCursor 基于游标分页，避免大表深分页时 OFFSET 的性能退化。
+// 默认按主键升序；orders 最多包含一个可排序列，此时以 (列, 主键) 作为游标，排序列应为非空列。
+// cursor 为空表示第一页，返回的下一页游标为空表示没有更多数据。需要总数或跳页时请使用 Paginate。
+func (s *Service[T]) PaginateCursor(ctx context.Context, cursor string, size int, filters map[string][]string, orders []OrderOption) ([]T, string, error) {
+	if size <= 0 {
+		size = 10
+	}
+	if s.maxPageSize > 0 && size > s.maxPageSize {
+		size = s.maxPageSize
+	}
+	if err := s.checkLimits(filters, orders); err != nil {
+		return nil, "", err
+	}
+
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, "", err
+	}
+	primary := sch.PrioritizedPrimaryField
+	if primary == nil {
+		return nil, "", errors.New("primary key is not defined")
+	}
+
+	query, allowed := s.listQuery(ctx, filters)
+
+	var (
+		orderField *schema.Field
+		desc       bool
+	)
+	sanitized := sanitizeOrders(orders, s.sortableColumns(allowed))
+	if len(sanitized) > 1 {
+		return nil, "", fmt.Errorf("%w: cursor pagination supports a single order column", ErrTooManyOrders)
+	}
+	if len(sanitized) == 1 {
+		desc = sanitized[0].Desc
+		if field := sch.LookUpField(sanitized[0].Column); field != nil && field != primary {
+			orderField = field
+		}
+	}
+
+	if cursor != "" {
+		condition, err := decodeCursor(cursor, primary, orderField, desc)
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.Where(condition)
+	}
+
+	if orderField != nil {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: orderField.DBName}, Desc: desc})
+	}
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: primary.DBName}, Desc: desc})
+
+	var list []T
+	err = s.guard(func() error {
+		return query.Limit(size + 1).Find(&list).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(list) <= size {
+		return list, "", nil
+	}
+	list = list[:size]
+
+	next, err := encodeCursor(ctx, &list[size-1], primary, orderField)
+	if err != nil {
+		return nil, "", err
+	}
+	return list, next, nil
+}
+
+func encodeCursor(ctx context.Context, last interface{}, primary, orderField *schema.Field) (string, error) {
+	elem := reflect.ValueOf(last).Elem()
+
+	key, _ := primary.ValueOf(ctx, elem)
+	payload := cursorPayload{}
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	payload.Key = raw
+
+	if orderField != nil {
+		value, _ := orderField.ValueOf(ctx, elem)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("encode cursor: %w", err)
+		}
+		payload.Column = orderField.DBName
+		payload.Value = raw
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor 解码游标并生成下一页的条件：按主键时为 pk > key，按列排序时为 col > v OR (col = v AND pk > key)，降序时比较方向相反。
+func decodeCursor(cursor string, primary, orderField *schema.Field, desc bool) (clause.Expression, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	key, err := decodeCursorValue(payload.Key, primary)
+	if err != nil {
+		return nil, err
+	}
+	pk := clause.Column{Name: primary.DBName}
+
+	if orderField == nil {
+		if payload.Column != "" {
+			return nil, fmt.Errorf("%w: cursor was issued for order column %s", ErrInvalidCursor, payload.Column)
+		}
+		return cursorCompare(pk, key, desc), nil
+	}
+
+	if payload.Column != orderField.DBName {
+		return nil, fmt.Errorf("%w: cursor does not match order column %s", ErrInvalidCursor, orderField.DBName)
+	}
+	value, err := decodeCursorValue(payload.Value, orderField)
+	if err != nil {
+		return nil, err
+	}
+	col := clause.Column{Name: orderField.DBName}
+	return clause.Or(
+		cursorCompare(col, value, desc),
+		clause.And(clause.Eq{Column: col, Value: value}, cursorCompare(pk, key, desc)),
+	), nil
+}
+
+// decodeCursorValue 将游标中的值按字段类型解码，类型不符时返回 ErrInvalidCursor。
+func decodeCursorValue(raw json.RawMessage, field *schema.Field) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidCursor, field.DBName)
+	}
+	target := reflect.New(field.FieldType)
+	if err := json.Unmarshal(raw, target.Interface()); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidCursor, field.DBName, err)
+	}
+	return target.Elem().Interface(), nil
+}
+
+func cursorCompare(column clause.Column, value interface{}, desc bool) clause.Expression {
+	if desc {
+		return clause.Lt{Column: column, Value: value}
+	}
+	return clause.Gt{Column: column, Value: value}
+}
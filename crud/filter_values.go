@@ -0,0 +1,96 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidFilter 表示筛选值与字段类型不匹配。
+var ErrInvalidFilter = errors.New("invalid filter value")
+
+// lookupFilterField 返回筛选列对应的 schema 字段，未解析出 schema 时返回 nil。
+func lookupFilterField(query *gorm.DB, column string) *schema.Field {
+	if query == nil || query.Statement == nil || query.Statement.Schema == nil {
+		return nil
+	}
+	return query.Statement.Schema.LookUpField(column)
+}
+
+// coerceFilterValue 根据字段类型转换筛选值：布尔列接受 true/1/yes 等写法，数值列校验格式，枚举列校验取值范围。
+func coerceFilterValue(field *schema.Field, raw string) (interface{}, error) {
+	if field == nil {
+		return raw, nil
+	}
+
+	switch field.DataType {
+	case schema.Bool:
+		value, ok := parseBoolValue(raw)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s expects a boolean, got %q", ErrInvalidFilter, field.DBName, raw)
+		}
+		return value, nil
+	case schema.Int:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s expects an integer, got %q", ErrInvalidFilter, field.DBName, raw)
+		}
+		return value, nil
+	case schema.Uint:
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s expects an unsigned integer, got %q", ErrInvalidFilter, field.DBName, raw)
+		}
+		return value, nil
+	case schema.Float:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s expects a number, got %q", ErrInvalidFilter, field.DBName, raw)
+		}
+		return value, nil
+	}
+
+	if options := enumOptions(field); len(options) > 0 {
+		for _, option := range options {
+			if option == raw {
+				return raw, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s must be one of %s, got %q", ErrInvalidFilter, field.DBName, strings.Join(options, ","), raw)
+	}
+
+	return raw, nil
+}
+
+func coerceFilterValues(field *schema.Field, values []string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(values))
+	for _, raw := range values {
+		value, err := coerceFilterValue(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// enumOptions 解析 gorm 标签中形如 type:enum('a','b') 的可选值。
+func enumOptions(field *schema.Field) []string {
+	typ := strings.TrimSpace(field.TagSettings["TYPE"])
+	if len(typ) < 6 || !strings.EqualFold(typ[:5], "enum(") || !strings.HasSuffix(typ, ")") {
+		return nil
+	}
+
+	options := make([]string, 0)
+	for _, part := range strings.Split(typ[5:len(typ)-1], ",") {
+		option := strings.Trim(strings.TrimSpace(part), `'"`)
+		if option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,67 @@
+package crud
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// Operation 标识触发 AfterCommit 回调的写操作类型。
+type Operation string
+
+const (
+	OpCreate  Operation = "create"
+	OpUpdate  Operation = "update"
+	OpUpsert  Operation = "upsert"
+	OpDelete  Operation = "delete"
+	OpRestore Operation = "restore"
+	OpPurge   Operation = "purge"
+)
+
+// AfterCommitFunc 在写操作成功提交后调用，target 为实体指针、实体切片、主键、主键列表或清理截止时间。
+// InsertIgnore 传入的实体切片可能包含因冲突而被跳过的行，详见 InsertIgnore。
+type AfterCommitFunc func(ctx context.Context, op Operation, target interface{})
+
+// WithAfterCommit 设置写操作成功后的回调，常用于失效缓存。
+// 回调只在操作成功后触发；通过 WithTx 执行时回调延迟到事务提交后触发，回滚时丢弃。
+// 若 Service 绑定的是调用方自行开启的事务句柄，无法得知事务最终是否提交，回调会被丢弃并记录警告，此时请改用 WithTx。
+func WithAfterCommit[T any](fn AfterCommitFunc) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.afterCommit = fn
+	}
+}
+
+type pendingCommit struct {
+	ctx    context.Context
+	op     Operation
+	target interface{}
+}
+
+func (s *Service[T]) notifyCommit(ctx context.Context, op Operation, target interface{}) {
+	if s.afterCommit == nil {
+		return
+	}
+	if s.pending != nil {
+		*s.pending = append(*s.pending, pendingCommit{ctx: ctx, op: op, target: target})
+		return
+	}
+	if s.inExternalTx() {
+		logger.Warn("Service 绑定了外部事务，AfterCommit 回调已丢弃，请改用 WithTx",
+			zap.String("op", string(op)),
+		)
+		return
+	}
+	s.afterCommit(ctx, op, target)
+}
+
+// inExternalTx 判断 Service 是否绑定在调用方自行开启的事务上。
+func (s *Service[T]) inExternalTx() bool {
+	if s.db == nil || s.db.Statement == nil {
+		return false
+	}
+	_, ok := s.db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
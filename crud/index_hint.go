@@ -0,0 +1,61 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type indexHintContextKey struct{}
+
+// WithIndexHints 配置允许通过 ContextWithIndexHint 指定的索引名白名单。
+func WithIndexHints[T any](names ...string) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.indexHints = toColumnSet(names)
+	}
+}
+
+// ContextWithIndexHint 为本次列表查询指定 USE INDEX 提示，索引名必须在 WithIndexHints 白名单内，
+// 否则查询返回 ErrInvalidFilter。仅在规划器选错索引时作为兜底手段使用。
+func ContextWithIndexHint(ctx context.Context, name string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, indexHintContextKey{}, strings.TrimSpace(name))
+}
+
+// applyIndexHint 校验并附加上下文中的索引提示。
+func (s *Service[T]) applyIndexHint(ctx context.Context, query *gorm.DB) *gorm.DB {
+	if ctx == nil {
+		return query
+	}
+	name, _ := ctx.Value(indexHintContextKey{}).(string)
+	if name == "" {
+		return query
+	}
+	if !s.indexHints[name] || !columnNamePattern.MatchString(name) {
+		_ = query.AddError(fmt.Errorf("%w: index %s is not allowed", ErrInvalidFilter, name))
+		return query
+	}
+	return query.Clauses(useIndex{name: name})
+}
+
+// useIndex 在 FROM 子句后追加 USE INDEX，实现方式与 gorm.io/hints 相同。
+type useIndex struct {
+	name string
+}
+
+func (h useIndex) ModifyStatement(stmt *gorm.Statement) {
+	from := stmt.Clauses["FROM"]
+	from.AfterExpression = h
+	stmt.Clauses["FROM"] = from
+}
+
+func (h useIndex) Build(builder clause.Builder) {
+	builder.WriteString("USE INDEX (")
+	builder.WriteQuoted(h.name)
+	builder.WriteByte(')')
+}
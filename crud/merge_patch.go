@@ -0,0 +1,116 @@
+package crud
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidPatch 表示合并补丁不是合法的 JSON，或目标列当前值无法解析为 JSON。
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// MergePatch 按 RFC 7386 对 JSON 列执行合并补丁：补丁中的 null 删除对应键，对象逐层合并，其余值直接替换。
+// 读取、合并与写回在同一事务内完成，并对该行加锁以避免并发更新丢失，返回合并后的文档。
+func (s *Service[T]) MergePatch(ctx context.Context, id, column string, patch []byte) (json.RawMessage, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+	field := sch.LookUpField(strings.TrimSpace(column))
+	if field == nil || field.DBName == "" || !field.Updatable || field.PrimaryKey {
+		return nil, fmt.Errorf("%w: %s is not updatable", ErrInvalidColumn, column)
+	}
+
+	patchValue, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	condition, err := s.idCondition(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []byte
+	err = s.guard(func() error {
+		return s.session(ctx).Transaction(func(tx *gorm.DB) error {
+			var current []byte
+			query := s.applyScopeFilters(ctx, tx.Model(new(T))).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				Select(field.DBName).Where(condition)
+			if query.Error != nil {
+				return query.Error
+			}
+			if err := query.Row().Scan(&current); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return gorm.ErrRecordNotFound
+				}
+				return err
+			}
+
+			var target interface{}
+			if len(bytes.TrimSpace(current)) > 0 {
+				value, err := decodeJSON(current)
+				if err != nil {
+					return fmt.Errorf("%w: current %s value: %v", ErrInvalidPatch, field.DBName, err)
+				}
+				target = value
+			}
+
+			result, err := json.Marshal(mergePatch(target, patchValue))
+			if err != nil {
+				return err
+			}
+			merged = result
+			return tx.Model(new(T)).Where(condition).Update(field.DBName, string(result)).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyCommit(ctx, OpUpdate, id)
+	return merged, nil
+}
+
+// mergePatch 实现 RFC 7386 的 MergePatch 算法。
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// decodeJSON 解码 JSON 并保留数字的原始精度。
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
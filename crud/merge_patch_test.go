@@ -0,0 +1,81 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type patchDoc struct {
+	ID       uint
+	Settings string
+}
+
+func newTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestMergePatchRoundTrip(t *testing.T) {
+	db := newTestDB(t, &patchDoc{})
+	if err := db.Create(&patchDoc{ID: 1, Settings: `{"theme":"dark","lang":"en","notify":{"email":true,"sms":true}}`}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	svc := NewService[patchDoc](db)
+	merged, err := svc.MergePatch(context.Background(), "1", "settings", []byte(`{"lang":null,"notify":{"sms":false}}`))
+	if err != nil {
+		t.Fatalf("MergePatch: %v", err)
+	}
+
+	want := map[string]interface{}{"theme": "dark", "notify": map[string]interface{}{"email": true, "sms": false}}
+	assertJSONEqual(t, merged, want)
+
+	var stored patchDoc
+	if err := db.First(&stored, 1).Error; err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	assertJSONEqual(t, []byte(stored.Settings), want)
+}
+
+func TestMergePatchNotFound(t *testing.T) {
+	db := newTestDB(t, &patchDoc{})
+	svc := NewService[patchDoc](db)
+
+	_, err := svc.MergePatch(context.Background(), "42", "settings", []byte(`{"a":1}`))
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("err = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func assertJSONEqual(t *testing.T, data []byte, want interface{}) {
+	t.Helper()
+	var got interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("got %s, want %s", gotJSON, wantJSON)
+	}
+}
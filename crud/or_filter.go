@@ -0,0 +1,41 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrFilterKey 为 OR 分组筛选的参数名，每个值为一个 列=值 的等值条件，例如 __or=name=foo&__or=email=foo
+// 生成 (name = 'foo' OR email = 'foo')，整体再与其他筛选按 AND 组合。不支持嵌套与其他操作符。
+const OrFilterKey = "__or"
+
+// applyOrGroup 将 OR 分组中的等值条件用括号包裹后追加到查询，列不在白名单内的条件会被忽略。
+func applyOrGroup(query *gorm.DB, values []string, allowed map[string]bool) *gorm.DB {
+	conditions := make([]clause.Expression, 0, len(values))
+	for _, pair := range normalizeFilterValues(values) {
+		column, raw, ok := strings.Cut(pair, "=")
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			_ = query.AddError(fmt.Errorf("%w: %s expects column=value, got %q", ErrInvalidFilter, OrFilterKey, pair))
+			return query
+		}
+		if !allowed[column] {
+			continue
+		}
+
+		value, err := coerceFilterValue(lookupFilterField(query, column), strings.TrimSpace(raw))
+		if err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+		conditions = append(conditions, clause.Eq{Column: clause.Column{Name: column}, Value: value})
+	}
+
+	if len(conditions) == 0 {
+		return query
+	}
+	return query.Where(clause.And(clause.Or(conditions...)))
+}
@@ -0,0 +1,80 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type contact struct {
+	ID     uint
+	Name   string
+	Email  string
+	Status string
+}
+
+func TestOrFilterGroupSQL(t *testing.T) {
+	db := newTestDB(t, &contact{})
+	filters := map[string][]string{
+		OrFilterKey: {"name=foo", "email=foo"},
+		"status":    {"active"},
+	}
+	allowed := map[string]bool{"name": true, "email": true, "status": true}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return ApplyFilters(tx.Model(&contact{}), filters, allowed).Find(&[]contact{})
+	})
+
+	// map 遍历顺序不固定，两种拼接顺序都可接受，但 OR 必须整体括起来。
+	group := "(`name` = \"foo\" OR `email` = \"foo\")"
+	status := "`status` = \"active\""
+	prefix := "SELECT * FROM `contacts` WHERE "
+	if sql != prefix+group+" AND "+status && sql != prefix+status+" AND "+group {
+		t.Fatalf("unexpected SQL: %s", sql)
+	}
+}
+
+func TestOrFilterGroupCombinesWithAnd(t *testing.T) {
+	db := newTestDB(t, &contact{})
+	seed := []contact{
+		{Name: "foo", Email: "a@example.com", Status: "active"},
+		{Name: "bar", Email: "foo", Status: "active"},
+		{Name: "foo", Email: "b@example.com", Status: "disabled"},
+		{Name: "baz", Email: "c@example.com", Status: "active"},
+	}
+	if err := db.Create(&seed).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	svc := NewService[contact](db)
+	count, err := svc.Count(context.Background(), map[string][]string{
+		OrFilterKey: {"name=foo", "email=foo"},
+		"status":    {"active"},
+	})
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("count = %d, want 2", count)
+	}
+}
+
+func TestOrFilterTermsCountTowardMaxFilters(t *testing.T) {
+	db := newTestDB(t, &contact{})
+	svc := NewService[contact](db, WithMaxFilters[contact](3))
+	ctx := context.Background()
+
+	if _, err := svc.Count(ctx, map[string][]string{OrFilterKey: {"name=a", "name=b", "name=c"}}); err != nil {
+		t.Fatalf("Count within limit: %v", err)
+	}
+	for name, filters := range map[string]map[string][]string{
+		"or terms":   {OrFilterKey: {"name=a", "name=b", "email=c", "email=d"}},
+		"like terms": {"name__like": {"a", "b"}, "email__like": {"c", "d"}},
+	} {
+		if _, err := svc.Count(ctx, filters); !errors.Is(err, ErrTooManyFilters) {
+			t.Errorf("%s: got %v, want ErrTooManyFilters", name, err)
+		}
+	}
+}
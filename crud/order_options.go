@@ -2,7 +2,8 @@ package crud
 
 import "strings"
 
-// ParseOrderOptions 解析排序参数。
+// ParseOrderOptions 解析排序参数，格式为 列[:方向[:排序规则]]，例如 name:asc:utf8mb4_turkish_ci；
+// 逗号分隔多个排序列，例如 order=-created_at,name 依次按 created_at 降序、name 升序排序。
 func ParseOrderOptions(values map[string][]string) []OrderOption {
 	rawOrders := make([]string, 0, len(values))
 	for _, key := range []string{"order", "sort", "order_by", "orderBy"} {
@@ -13,9 +14,10 @@ func ParseOrderOptions(values map[string][]string) []OrderOption {
 
 	options := make([]OrderOption, 0, len(rawOrders))
 	for _, raw := range rawOrders {
-		opt, ok := parseOrderOption(raw)
-		if ok {
-			options = append(options, opt)
+		for _, segment := range strings.Split(raw, ",") {
+			if opt, ok := parseOrderOption(segment); ok {
+				options = append(options, opt)
+			}
 		}
 	}
 	return options
@@ -28,7 +30,7 @@ func parseOrderOption(raw string) (OrderOption, bool) {
 	}
 
 	parts := strings.FieldsFunc(trimmed, func(r rune) bool {
-		return r == ' ' || r == ':' || r == ','
+		return r == ' ' || r == ':'
 	})
 
 	if len(parts) == 0 {
@@ -61,5 +63,10 @@ func parseOrderOption(raw string) (OrderOption, bool) {
 		}
 	}
 
-	return OrderOption{Column: column, Desc: desc}, true
+	collation := ""
+	if len(parts) > 2 {
+		collation = strings.TrimSpace(parts[2])
+	}
+
+	return OrderOption{Column: column, Desc: desc, Collation: collation}, true
 }
@@ -0,0 +1,44 @@
+package crud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOrderOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string][]string
+		want   []OrderOption
+	}{
+		{
+			name:   "comma separated columns keep their order",
+			values: map[string][]string{"order": {"-created_at,name"}},
+			want:   []OrderOption{{Column: "created_at", Desc: true}, {Column: "name"}},
+		},
+		{
+			name:   "colon and space directions",
+			values: map[string][]string{"order": {"name:desc, age asc"}},
+			want:   []OrderOption{{Column: "name", Desc: true}, {Column: "age"}},
+		},
+		{
+			name:   "collation",
+			values: map[string][]string{"sort": {"name:asc:utf8mb4_turkish_ci"}},
+			want:   []OrderOption{{Column: "name", Collation: "utf8mb4_turkish_ci"}},
+		},
+		{
+			name:   "empty segments are skipped",
+			values: map[string][]string{"order": {",name,,-"}},
+			want:   []OrderOption{{Column: "name"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseOrderOptions(tt.values)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseOrderOptions(%v) = %+v, want %+v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package crud
+
+import (
+	"context"
+	"testing"
+)
+
+type pageItem struct {
+	ID   uint
+	Name string
+}
+
+func TestPaginateClampsSizeToMaxPageSize(t *testing.T) {
+	db := newTestDB(t, &pageItem{})
+	if err := db.Create(&[]pageItem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	svc := NewService[pageItem](db, WithMaxPageSize[pageItem](2))
+	list, total, err := svc.Paginate(context.Background(), 2, 1000, nil, nil)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if total != 5 {
+		t.Fatalf("total = %d, want 5", total)
+	}
+	if len(list) != 2 || list[0].ID != 3 || list[1].ID != 4 {
+		t.Fatalf("page 2 = %+v, want ids 3 and 4", list)
+	}
+}
@@ -0,0 +1,50 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidID 表示传入的主键无法按主键字段类型解析。
+var ErrInvalidID = errors.New("invalid id")
+
+// primaryKey 返回实体的主键字段。
+func (s *Service[T]) primaryKey() (*schema.Field, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+	if sch.PrioritizedPrimaryField == nil {
+		return nil, errors.New("primary key is not defined")
+	}
+	return sch.PrioritizedPrimaryField, nil
+}
+
+// parsePrimaryKey 按主键字段类型解析 id：有符号/无符号整数分别解析，字符串主键去除首尾空白后使用。
+func parsePrimaryKey(field *schema.Field, id string) (interface{}, error) {
+	trimmed := strings.TrimSpace(id)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalidID)
+	}
+
+	switch field.DataType {
+	case schema.Int:
+		value, err := strconv.ParseInt(trimmed, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidID, id)
+		}
+		return value, nil
+	case schema.Uint:
+		value, err := strconv.ParseUint(trimmed, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an unsigned integer", ErrInvalidID, id)
+		}
+		return value, nil
+	default:
+		return trimmed, nil
+	}
+}
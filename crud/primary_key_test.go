@@ -0,0 +1,26 @@
+package crud
+
+import (
+	"context"
+	"testing"
+)
+
+type stringKeyed struct {
+	Code string `gorm:"primaryKey"`
+	Name string
+}
+
+func TestFindByIDTrimsStringKeys(t *testing.T) {
+	db := newTestDB(t, &stringKeyed{})
+	if err := db.Create(&stringKeyed{Code: "abc", Name: "found"}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	entity, err := NewService[stringKeyed](db).FindByID(context.Background(), " abc ")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if entity.Name != "found" {
+		t.Fatalf("got %+v, want the abc row", entity)
+	}
+}
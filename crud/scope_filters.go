@@ -0,0 +1,44 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type scopeFiltersContextKey struct{}
+
+// ContextWithScopeFilters 为本次按主键的读写（FindByID、DeleteByID、HardDeleteByID、RestoreByIDs、MergePatch、Touch）
+// 附加强制筛选条件，语法与列表筛选相同，不满足条件的记录视为不存在。列不在筛选白名单内时返回 ErrInvalidFilter，而不是忽略该条件。
+// Handler 通过它将 WithDefaultFilters 与 WithPathFilters 同样应用到 Get、Delete、Restore 与 Patch 接口。
+func ContextWithScopeFilters(ctx context.Context, filters map[string][]string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	copied := make(map[string][]string, len(filters))
+	for key, values := range filters {
+		copied[key] = values
+	}
+	return context.WithValue(ctx, scopeFiltersContextKey{}, copied)
+}
+
+// applyScopeFilters 附加上下文中的强制筛选条件。
+func (s *Service[T]) applyScopeFilters(ctx context.Context, query *gorm.DB) *gorm.DB {
+	if ctx == nil {
+		return query
+	}
+	filters, _ := ctx.Value(scopeFiltersContextKey{}).(map[string][]string)
+	if len(filters) == 0 {
+		return query
+	}
+
+	allowed := s.filterableColumns(columnAllowlist(query, new(T)))
+	for key := range filters {
+		if column, _ := parseFilterKey(key); !allowed[column] {
+			_ = query.AddError(fmt.Errorf("%w: scope filter column %s is not filterable", ErrInvalidFilter, column))
+			return query
+		}
+	}
+	return ApplyFilters(query, filters, allowed)
+}
@@ -0,0 +1,130 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrSoftDeleteUnsupported 表示实体未定义 gorm.DeletedAt 软删除字段。
+var ErrSoftDeleteUnsupported = errors.New("entity does not support soft delete")
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// RestoreByID 恢复单条已软删除的记录，未恢复任何记录时返回 gorm.ErrRecordNotFound，
+// 实体未定义 gorm.DeletedAt 时返回 ErrSoftDeleteUnsupported。
+func (s *Service[T]) RestoreByID(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("id is required")
+	}
+
+	restored, err := s.RestoreByIDs(ctx, []string{id})
+	if err != nil {
+		return err
+	}
+	if restored == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RestoreByIDs 批量恢复已软删除的记录，联合主键的每个 id 使用 tenant_id:1,id:42 格式，返回实际恢复的行数。
+func (s *Service[T]) RestoreByIDs(ctx context.Context, ids []string) (int64, error) {
+	ids = normalizeFilterValues(ids)
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	_, deletedAt, err := s.softDeleteSchema()
+	if err != nil {
+		return 0, err
+	}
+
+	condition, err := s.idsCondition(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	var result *gorm.DB
+	err = s.guard(func() error {
+		result = s.applyScopeFilters(ctx, s.session(ctx).Unscoped().Model(new(T))).
+			Where(condition).
+			Where(clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{clause.Column{Name: deletedAt.DBName}}}).
+			Update(deletedAt.DBName, nil)
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected > 0 {
+		s.notifyCommit(ctx, OpRestore, ids)
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeDeletedBefore 永久删除软删除时间早于 cutoff 的记录，返回删除的行数。
+func (s *Service[T]) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if cutoff.IsZero() {
+		return 0, errors.New("cutoff is required")
+	}
+
+	_, deletedAt, err := s.softDeleteSchema()
+	if err != nil {
+		return 0, err
+	}
+
+	var result *gorm.DB
+	err = s.guard(func() error {
+		result = s.session(ctx).Unscoped().
+			Where(clause.Lt{Column: clause.Column{Name: deletedAt.DBName}, Value: cutoff}).
+			Delete(new(T))
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected > 0 {
+		s.notifyCommit(ctx, OpPurge, cutoff)
+	}
+	return result.RowsAffected, nil
+}
+
+// softDeleteSchema 返回实体 schema 及其 gorm.DeletedAt 字段，不支持软删除时返回 ErrSoftDeleteUnsupported。
+func (s *Service[T]) softDeleteSchema() (*schema.Schema, *schema.Field, error) {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, field := range sch.Fields {
+		if field.DBName != "" && field.FieldType == deletedAtType {
+			return sch, field, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrSoftDeleteUnsupported, sch.Name)
+}
+
+// WithSoftDeleteColumn 为未使用 gorm.DeletedAt 的旧表配置软删除标记列，例如 ("is_deleted", 1) 或 ("status", "deleted")。
+// 配置后 DeleteByID 改为将该列更新为 deletedValue，查询与更新会自动排除已删除的记录；原生 DeletedAt 行为不受影响。
+func WithSoftDeleteColumn[T any](column string, deletedValue interface{}) ServiceOption[T] {
+	return func(s *Service[T]) {
+		s.softDeleteColumn = strings.TrimSpace(column)
+		s.softDeleteValue = deletedValue
+	}
+}
+
+// excludeSoftDeleted 排除软删除标记列等于删除值的记录，列为 NULL 的记录视为未删除。
+func (s *Service[T]) excludeSoftDeleted(tx *gorm.DB) *gorm.DB {
+	column := clause.Column{Name: s.softDeleteColumn}
+	return tx.Where(clause.Or(
+		clause.Expr{SQL: "? IS NULL", Vars: []interface{}{column}},
+		clause.Neq{Column: column, Value: s.softDeleteValue},
+	))
+}
@@ -0,0 +1,69 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Touch 按主键（联合主键使用 tenant_id:1,id:42 格式）仅刷新自动更新时间列（如 updated_at），不修改业务字段，适用于心跳、最近活跃等场景。
+// 未匹配到记录时返回 gorm.ErrRecordNotFound。
+func (s *Service[T]) Touch(ctx context.Context, id string) error {
+	sch, err := s.parseSchema()
+	if err != nil {
+		return err
+	}
+	condition, err := s.idCondition(id)
+	if err != nil {
+		return err
+	}
+
+	now := s.db.NowFunc()
+	values := make(map[string]interface{})
+	for _, field := range sch.Fields {
+		if field.AutoUpdateTime > 0 && field.DBName != "" {
+			values[field.DBName] = autoUpdateValue(field, now)
+		}
+	}
+	if len(values) == 0 {
+		return errors.New("auto update time column is not defined")
+	}
+
+	session := s.session(ctx)
+	err = s.guard(func() error {
+		result := s.applyScopeFilters(ctx, session.Model(new(T))).
+			Where(condition).
+			Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyCommit(ctx, OpUpdate, id)
+	return nil
+}
+
+// autoUpdateValue 按字段类型与精度生成自动更新时间的取值，与 gorm 的 autoUpdateTime 行为一致。
+func autoUpdateValue(field *schema.Field, now time.Time) interface{} {
+	if field.DataType == schema.Time {
+		return now
+	}
+	switch field.AutoUpdateTime {
+	case schema.UnixNanosecond:
+		return now.UnixNano()
+	case schema.UnixMillisecond:
+		return now.UnixMilli()
+	default:
+		return now.Unix()
+	}
+}
@@ -0,0 +1,32 @@
+package crud
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WithTx 在同一事务中执行 fn，fn 收到绑定事务句柄的 Service，返回错误时整体回滚。
+// 事务内写操作的 AfterCommit 回调在事务提交成功后按顺序触发，回滚时丢弃。
+// 嵌套调用时内层以保存点执行，其回调在内层成功后并入外层，内层回滚的写操作不会触发回调。
+func (s *Service[T]) WithTx(ctx context.Context, fn func(txSvc *Service[T]) error) error {
+	pending := make([]pendingCommit, 0)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txSvc := *s
+		txSvc.db = tx
+		txSvc.pending = &pending
+		return fn(&txSvc)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.pending != nil {
+		*s.pending = append(*s.pending, pending...)
+		return nil
+	}
+	for _, item := range pending {
+		s.afterCommit(item.ctx, item.op, item.target)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type txItem struct {
+	ID   uint
+	Name string
+}
+
+func TestWithTxNestedRollbackDropsHooks(t *testing.T) {
+	db := newTestDB(t, &txItem{})
+
+	var names []string
+	svc := NewService[txItem](db, WithAfterCommit[txItem](func(_ context.Context, op Operation, target interface{}) {
+		if item, ok := target.(*txItem); ok && op == OpCreate {
+			names = append(names, item.Name)
+		}
+	}))
+
+	errInner := errors.New("inner failed")
+	err := svc.WithTx(context.Background(), func(outer *Service[txItem]) error {
+		if err := outer.SaveOrUpdate(context.Background(), &txItem{Name: "outer"}); err != nil {
+			return err
+		}
+		err := outer.WithTx(context.Background(), func(inner *Service[txItem]) error {
+			if err := inner.SaveOrUpdate(context.Background(), &txItem{Name: "rolled back"}); err != nil {
+				return err
+			}
+			return errInner
+		})
+		if !errors.Is(err, errInner) {
+			t.Fatalf("inner WithTx: %v", err)
+		}
+		if names != nil {
+			t.Fatalf("hooks fired before commit: %v", names)
+		}
+		return outer.WithTx(context.Background(), func(inner *Service[txItem]) error {
+			return inner.SaveOrUpdate(context.Background(), &txItem{Name: "committed"})
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+
+	if want := []string{"outer", "committed"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("hooks = %v, want %v", names, want)
+	}
+	var count int64
+	if err := db.Model(&txItem{}).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("rows = %d, want 2", count)
+	}
+}
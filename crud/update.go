@@ -0,0 +1,63 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Update 按主键仅更新指定列，零值同样会写入，用于将布尔值置为 false、字符串置为空等场景。
+// 未指定列时等同于 SaveOrUpdate；列必须是可更新的非主键字段，否则返回 ErrInvalidColumn。
+func (s *Service[T]) Update(ctx context.Context, entity *T, columns ...string) error {
+	if len(columns) == 0 {
+		return s.SaveOrUpdate(ctx, entity)
+	}
+	if entity == nil {
+		return errors.New("entity is nil")
+	}
+
+	session := s.session(ctx)
+	stmt := &gorm.Statement{DB: session, Context: ctx}
+	if err := stmt.Parse(entity); err != nil {
+		return err
+	}
+	sch := stmt.Schema
+	primary := sch.PrioritizedPrimaryField
+	if primary == nil {
+		return errors.New("primary key is not defined")
+	}
+
+	elem := reflect.ValueOf(entity).Elem()
+	if _, zero := primary.ValueOf(ctx, elem); zero {
+		return fmt.Errorf("%w: primary key is empty", ErrInvalidID)
+	}
+
+	selected := make([]string, 0, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		field := sch.LookUpField(strings.TrimSpace(name))
+		if field == nil || field.DBName == "" || !field.Updatable || field.PrimaryKey {
+			return fmt.Errorf("%w: %s is not updatable", ErrInvalidColumn, name)
+		}
+		if !seen[field.DBName] {
+			seen[field.DBName] = true
+			selected = append(selected, field.DBName)
+		}
+	}
+	for _, field := range sch.Fields {
+		if field.AutoUpdateTime > 0 && field.DBName != "" && !seen[field.DBName] {
+			seen[field.DBName] = true
+			selected = append(selected, field.DBName)
+		}
+	}
+
+	if err := s.guard(func() error { return session.Model(entity).Select(selected).Updates(entity).Error }); err != nil {
+		return err
+	}
+	s.notifyCommit(ctx, OpUpdate, entity)
+	return nil
+}
@@ -0,0 +1,134 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const defaultBatchSize = 100
+
+// BatchCreate 在单个事务内分批插入实体，任一批失败整体回滚；batchSize <= 0 时使用 100。
+func (s *Service[T]) BatchCreate(ctx context.Context, entities []T, batchSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	err := s.guard(func() error {
+		return s.session(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.CreateInBatches(&entities, batchSize).Error
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyCommit(ctx, OpCreate, entities)
+	return nil
+}
+
+// Upsert 批量写入实体，冲突时更新 updateColumns（为空时更新除主键外的全部列）。
+// conflictColumns 可包含多列以匹配联合唯一索引，例如 (vendor_id, sku)。
+//
+// 注意：Postgres/SQLite 生成 ON CONFLICT (cols) DO UPDATE，冲突目标必须与某个唯一索引完全一致；
+// MySQL 生成 ON DUPLICATE KEY UPDATE，会忽略 conflictColumns，任何唯一索引（含主键）冲突都会触发更新。
+func (s *Service[T]) Upsert(ctx context.Context, entities []T, conflictColumns []string, updateColumns ...string) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if len(conflictColumns) == 0 {
+		return errors.New("conflict columns are required")
+	}
+
+	sch, err := s.parseSchema()
+	if err != nil {
+		return err
+	}
+
+	conflict, err := schemaColumns(sch, conflictColumns)
+	if err != nil {
+		return err
+	}
+
+	onConflict := clause.OnConflict{Columns: conflict}
+	if len(updateColumns) == 0 {
+		onConflict.UpdateAll = true
+	} else {
+		updates, err := schemaColumns(sch, updateColumns)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(updates))
+		for _, col := range updates {
+			names = append(names, col.Name)
+		}
+		onConflict.DoUpdates = clause.AssignmentColumns(names)
+	}
+
+	err = s.guard(func() error {
+		return s.session(ctx).Clauses(onConflict).CreateInBatches(&entities, defaultBatchSize).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyCommit(ctx, OpUpsert, entities)
+	return nil
+}
+
+// InsertIgnore 分批插入实体，冲突的行直接跳过而不更新，返回实际插入的行数，适用于幂等的追加写入。
+// conflictColumns 为空时不指定冲突目标；MySQL 会忽略 conflictColumns，任何唯一索引冲突都会跳过该行。
+//
+// 注意：至少插入一行时 AfterCommit 回调以 OpCreate 收到完整的 entities，其中可能包含被跳过、并未写入的行，
+// 数据库无法区分哪些行被跳过，且 MySQL 下被跳过的行也可能回填了自增主键。回调应将其视为"可能写入"的候选集合，
+// 例如按唯一键失效缓存，而不要依据其中的主键推断已写入的记录。
+func (s *Service[T]) InsertIgnore(ctx context.Context, entities []T, conflictColumns []string) (int64, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	sch, err := s.parseSchema()
+	if err != nil {
+		return 0, err
+	}
+	conflict, err := schemaColumns(sch, conflictColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	var inserted int64
+	err = s.guard(func() error {
+		result := s.session(ctx).Clauses(clause.OnConflict{Columns: conflict, DoNothing: true}).CreateInBatches(&entities, defaultBatchSize)
+		inserted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return inserted, err
+	}
+
+	if inserted > 0 {
+		s.notifyCommit(ctx, OpCreate, entities)
+	}
+	return inserted, nil
+}
+
+// schemaColumns 校验列名均为实体的真实列并转换为 clause.Column。
+func schemaColumns(sch *schema.Schema, names []string) ([]clause.Column, error) {
+	columns := make([]clause.Column, 0, len(names))
+	for _, name := range names {
+		trimmed := strings.TrimSpace(name)
+		if _, ok := sch.FieldsByDBName[trimmed]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns = append(columns, clause.Column{Name: trimmed})
+	}
+	return columns, nil
+}
@@ -0,0 +1,55 @@
+package crud
+
+import (
+	"context"
+	"testing"
+)
+
+type vendorItem struct {
+	ID       uint
+	VendorID uint   `gorm:"uniqueIndex:idx_vendor_sku"`
+	SKU      string `gorm:"uniqueIndex:idx_vendor_sku"`
+	Price    int
+	Stock    int
+}
+
+func TestUpsertCompositeConflict(t *testing.T) {
+	db := newTestDB(t, &vendorItem{})
+	seed := []vendorItem{
+		{VendorID: 1, SKU: "A", Price: 10, Stock: 5},
+		{VendorID: 2, SKU: "A", Price: 20, Stock: 5},
+	}
+	if err := db.Create(&seed).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	svc := NewService[vendorItem](db)
+	err := svc.Upsert(context.Background(), []vendorItem{
+		{VendorID: 1, SKU: "A", Price: 15, Stock: 9},
+		{VendorID: 1, SKU: "B", Price: 30, Stock: 1},
+	}, []string{"vendor_id", "sku"}, "price")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	var items []vendorItem
+	if err := db.Order("vendor_id, sku").Find(&items).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []vendorItem{
+		{ID: seed[0].ID, VendorID: 1, SKU: "A", Price: 15, Stock: 5},
+		{VendorID: 1, SKU: "B", Price: 30, Stock: 1},
+		{ID: seed[1].ID, VendorID: 2, SKU: "A", Price: 20, Stock: 5},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(items), len(want), items)
+	}
+	for i, item := range items {
+		if want[i].ID == 0 {
+			want[i].ID = item.ID
+		}
+		if item != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, item, want[i])
+		}
+	}
+}
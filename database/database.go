@@ -1,9 +1,10 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"os"
 	"sync"
 	"time"
 
@@ -23,8 +24,46 @@ const (
 	defaultConnMaxIdleTime = 30 * time.Minute
 )
 
-// NewDB 返回基于环境配置初始化的 GORM 单例实例。
+// PoolConfig 为连接池配置，零值字段使用默认值：最大连接 120、最大空闲 60、连接最长存活 2 小时、空闲最长 30 分钟。
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// withDefaults 填充未设置的字段并校验空闲连接数不超过最大连接数。
+func (cfg PoolConfig) withDefaults() (PoolConfig, error) {
+	if cfg.MaxOpenConns <= 0 {
+		cfg.MaxOpenConns = defaultMaxOpenConns
+	}
+	if cfg.MaxIdleConns <= 0 {
+		cfg.MaxIdleConns = min(defaultMaxIdleConns, cfg.MaxOpenConns)
+	}
+	if cfg.ConnMaxLifetime <= 0 {
+		cfg.ConnMaxLifetime = defaultConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime <= 0 {
+		cfg.ConnMaxIdleTime = defaultConnMaxIdleTime
+	}
+	if cfg.MaxIdleConns > cfg.MaxOpenConns {
+		return cfg, fmt.Errorf("max idle conns %d exceeds max open conns %d", cfg.MaxIdleConns, cfg.MaxOpenConns)
+	}
+	return cfg, nil
+}
+
+// NewDB 返回基于环境配置初始化的 GORM 单例实例，连接池使用默认配置。
 func NewDB() (*gorm.DB, error) {
+	return NewDBWithConfig(PoolConfig{})
+}
+
+// NewDBWithConfig 与 NewDB 相同，但允许覆盖连接池配置；单例已初始化时直接返回，配置不再生效。
+func NewDBWithConfig(cfg PoolConfig) (*gorm.DB, error) {
+	pool, err := cfg.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -36,8 +75,17 @@ func NewDB() (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	replicas, err := replicaDSNs()
+	if err != nil {
+		return nil, err
+	}
 
-	gormDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	return openInstance(mysql.Open(dsn), pool, replicas)
+}
+
+// openInstance 打开连接、配置连接池并注册从库，成功后保存为单例；调用方需持有 mu。
+func openInstance(dialector gorm.Dialector, pool PoolConfig, replicas []string) (*gorm.DB, error) {
+	gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: newGormLogger()})
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
@@ -46,22 +94,81 @@ func NewDB() (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("database handle: %w", err)
 	}
-	configureConnectionPool(handle)
+	configureConnectionPool(handle, pool)
+	if err := registerReplicas(gormDB, replicas, pool); err != nil {
+		_ = handle.Close()
+		return nil, err
+	}
 	dbInstance = gormDB
 	return dbInstance, nil
 }
 
-func resolveDSN() (string, error) {
-	dsn := os.Getenv("MYSQL_DSN")
-	if dsn == "" {
-		return "", fmt.Errorf("env MYSQL_DSN is required")
+// Ping 检查单例数据库连接是否可用，NewDB 尚未成功调用时返回错误。
+func Ping(ctx context.Context) error {
+	handle, err := sqlHandle()
+	if err != nil {
+		return err
+	}
+	if err := handle.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭单例的主库与从库连接并清空单例，之后调用 NewDB 会按最新配置重新连接。
+// 主要用于进程退出与测试；重复调用为空操作。
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if dbInstance == nil {
+		return nil
+	}
+
+	var errs []error
+	if handle, err := dbInstance.DB(); err != nil {
+		errs = append(errs, fmt.Errorf("database handle: %w", err))
+	} else if err := handle.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close database: %w", err))
+	}
+	if err := closeHandles(replicaHandles); err != nil {
+		errs = append(errs, fmt.Errorf("close replicas: %w", err))
+	}
+
+	dbInstance = nil
+	replicaHandles = nil
+	return errors.Join(errs...)
+}
+
+// Stats 返回单例连接池的统计信息，便于导出监控指标；NewDB 尚未成功调用时返回零值。
+func Stats() sql.DBStats {
+	handle, err := sqlHandle()
+	if err != nil {
+		return sql.DBStats{}
+	}
+	return handle.Stats()
+}
+
+// sqlHandle 返回单例底层的 *sql.DB。
+func sqlHandle() (*sql.DB, error) {
+	mu.Lock()
+	instance := dbInstance
+	mu.Unlock()
+
+	if instance == nil {
+		return nil, errors.New("database is not initialized")
+	}
+
+	handle, err := instance.DB()
+	if err != nil {
+		return nil, fmt.Errorf("database handle: %w", err)
 	}
-	return dsn, nil
+	return handle, nil
 }
 
-func configureConnectionPool(handle *sql.DB) {
-	handle.SetMaxOpenConns(defaultMaxOpenConns)
-	handle.SetMaxIdleConns(defaultMaxIdleConns)
-	handle.SetConnMaxLifetime(defaultConnMaxLifetime)
-	handle.SetConnMaxIdleTime(defaultConnMaxIdleTime)
+func configureConnectionPool(handle *sql.DB, cfg PoolConfig) {
+	handle.SetMaxOpenConns(cfg.MaxOpenConns)
+	handle.SetMaxIdleConns(cfg.MaxIdleConns)
+	handle.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	handle.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 }
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Driver 标识数据库驱动。
+type Driver string
+
+const (
+	DriverMySQL    Driver = "mysql"
+	DriverPostgres Driver = "postgres"
+	DriverSQLite   Driver = "sqlite"
+)
+
+// Open 使用指定驱动与 DSN 初始化单例实例，与 NewDB 共享单例与连接池配置，适用于 Postgres 部署或 SQLite 内存库测试。
+// SQLite 连接池固定为单连接，避免 :memory: 库在不同连接间互不可见；单例已初始化时直接返回。
+func Open(driver Driver, dsn string) (*gorm.DB, error) {
+	if strings.TrimSpace(dsn) == "" {
+		return nil, fmt.Errorf("dsn is required")
+	}
+
+	dialector, err := dialectorFor(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := PoolConfig{}
+	if driver == DriverSQLite {
+		pool = PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1}
+	}
+	pool, err = pool.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if dbInstance != nil {
+		return dbInstance, nil
+	}
+	return openInstance(dialector, pool, nil)
+}
+
+func dialectorFor(driver Driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverMySQL:
+		if err := validateDSN(dsn); err != nil {
+			return nil, err
+		}
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+const (
+	defaultDBPort    = "3306"
+	defaultCharset   = "utf8mb4"
+	defaultLocation  = "Local"
+	defaultCollation = "utf8mb4_general_ci"
+)
+
+// resolveDSN 解析数据库连接串：显式的 MYSQL_DSN 优先，仅在未设置时才使用 DB_HOST 等离散变量拼装。
+// 两者同时存在时记录警告，提示可能残留了过期的 MYSQL_DSN。
+func resolveDSN() (string, error) {
+	dsn := os.Getenv("MYSQL_DSN")
+	host := os.Getenv("DB_HOST")
+
+	if dsn != "" {
+		if host != "" {
+			logger.Warn("同时设置了 MYSQL_DSN 与 DB_HOST，将使用 MYSQL_DSN 并忽略 DB_* 离散变量", zap.String("db_host", host))
+		}
+		if err := validateDSN(dsn); err != nil {
+			return "", err
+		}
+		logger.Info("数据库连接串来源: MYSQL_DSN")
+		return dsn, nil
+	}
+
+	if host != "" {
+		logger.Info("数据库连接串来源: DB_* 离散变量", zap.String("db_host", host))
+		return buildDSNFromEnv()
+	}
+
+	return "", fmt.Errorf("env MYSQL_DSN or DB_HOST is required")
+}
+
+// validateDSN 校验外部提供的 DSN，缺少 parseTime=true 时记录警告，避免时间字段解析异常。
+func validateDSN(dsn string) error {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return fmt.Errorf("parse MYSQL_DSN: %w", err)
+	}
+
+	if !cfg.ParseTime {
+		logger.Warn("MYSQL_DSN 未设置 parseTime=true，时间字段将无法解析为 time.Time", zap.String("addr", cfg.Addr))
+	}
+	return nil
+}
+
+// buildDSNFromEnv 使用 DB_HOST/DB_PORT/DB_USER/DB_PASSWORD/DB_NAME 等离散变量拼装 DSN，
+// 默认启用 utf8mb4、parseTime=true，并按 DB_LOC/DB_TIME_ZONE 设置时区。
+func buildDSNFromEnv() (string, error) {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(os.Getenv("DB_HOST"), envOrDefault("DB_PORT", defaultDBPort))
+	cfg.User = os.Getenv("DB_USER")
+	cfg.Passwd = os.Getenv("DB_PASSWORD")
+	cfg.DBName = os.Getenv("DB_NAME")
+	cfg.ParseTime = true
+	cfg.Collation = envOrDefault("DB_COLLATION", defaultCollation)
+	cfg.Params = map[string]string{"charset": envOrDefault("DB_CHARSET", defaultCharset)}
+
+	loc, err := time.LoadLocation(envOrDefault("DB_LOC", defaultLocation))
+	if err != nil {
+		return "", fmt.Errorf("load DB_LOC: %w", err)
+	}
+	cfg.Loc = loc
+
+	if tz := os.Getenv("DB_TIME_ZONE"); tz != "" {
+		cfg.Params["time_zone"] = "'" + tz + "'"
+	}
+
+	return cfg.FormatDSN(), nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// defaultSlowThreshold 为默认慢查询阈值，超过该耗时的 SQL 以 Error 级别记录。
+const defaultSlowThreshold = 200 * time.Millisecond
+
+var (
+	slowThreshold atomic.Int64
+	traceSQL      atomic.Bool
+)
+
+func init() {
+	slowThreshold.Store(int64(defaultSlowThreshold))
+}
+
+// SetSlowThreshold 设置慢查询阈值，<= 0 表示不记录慢查询；可在运行时调整。
+func SetSlowThreshold(d time.Duration) {
+	slowThreshold.Store(int64(d))
+}
+
+// SetTraceSQL 开启后所有 SQL 以 Debug 级别记录到 debug 日志，默认关闭。
+func SetTraceSQL(enabled bool) {
+	traceSQL.Store(enabled)
+}
+
+// gormLogger 将 GORM 日志桥接到 logger 包，并附带上下文中的 request id，便于与访问日志关联。
+type gormLogger struct {
+	level gormlogger.LogLevel
+}
+
+func newGormLogger() *gormLogger {
+	return &gormLogger{level: gormlogger.Warn}
+}
+
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logger.Info(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logger.Warn(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logger.Error(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+// Trace 记录执行失败与超过慢查询阈值的 SQL 到 error 日志，开启 SetTraceSQL 时其余 SQL 记录到 debug 日志；记录不存在不视为错误。
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	threshold := time.Duration(slowThreshold.Load())
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		logger.Error("SQL 执行失败", traceFields(ctx, elapsed, fc, zap.Error(err))...)
+	case threshold > 0 && elapsed > threshold && l.level >= gormlogger.Warn:
+		logger.Error("慢查询", traceFields(ctx, elapsed, fc, zap.Duration("threshold", threshold))...)
+	case traceSQL.Load() || l.level >= gormlogger.Info:
+		logger.Debug("SQL", traceFields(ctx, elapsed, fc)...)
+	}
+}
+
+func traceFields(ctx context.Context, elapsed time.Duration, fc func() (string, int64), extra ...zap.Field) []zap.Field {
+	sql, rows := fc()
+	fields := append(contextFields(ctx),
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+		zap.String("source", utils.FileWithLineNum()),
+	)
+	return append(fields, extra...)
+}
+
+func contextFields(ctx context.Context) []zap.Field {
+	if requestID, ok := logger.RequestIDFromContext(ctx); ok {
+		return []zap.Field{zap.String("request_id", requestID)}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// replicaHandles 为已打开的从库连接，受 mu 保护。
+var replicaHandles []*sql.DB
+
+// replicaDSNs 解析 MYSQL_REPLICA_DSNS（逗号分隔），未设置时返回空。
+func replicaDSNs() ([]string, error) {
+	raw := os.Getenv("MYSQL_REPLICA_DSNS")
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	dsns := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		dsn := strings.TrimSpace(part)
+		if dsn == "" {
+			continue
+		}
+		if err := validateDSN(dsn); err != nil {
+			return nil, fmt.Errorf("MYSQL_REPLICA_DSNS: %w", err)
+		}
+		dsns = append(dsns, dsn)
+	}
+	return dsns, nil
+}
+
+// registerReplicas 注册读写分离：读请求随机路由到从库，写请求与事务走主库，连接池配置同样作用于从库。
+func registerReplicas(gormDB *gorm.DB, dsns []string, pool PoolConfig) error {
+	if len(dsns) == 0 {
+		return nil
+	}
+
+	// 自行打开从库连接以便 Close 时一并关闭，dbresolver 本身不提供关闭入口。
+	dialectors := make([]gorm.Dialector, 0, len(dsns))
+	handles := make([]*sql.DB, 0, len(dsns))
+	for _, dsn := range dsns {
+		handle, err := sql.Open("mysql", dsn)
+		if err != nil {
+			closeHandles(handles)
+			return fmt.Errorf("open replica: %w", err)
+		}
+		handles = append(handles, handle)
+		dialectors = append(dialectors, mysql.New(mysql.Config{Conn: handle}))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(pool.MaxOpenConns).
+		SetMaxIdleConns(pool.MaxIdleConns).
+		SetConnMaxLifetime(pool.ConnMaxLifetime).
+		SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	if err := gormDB.Use(resolver); err != nil {
+		closeHandles(handles)
+		return fmt.Errorf("register replicas: %w", err)
+	}
+	replicaHandles = handles
+	logger.Info("已启用数据库读写分离", zap.Int("replicas", len(dsns)))
+	return nil
+}
+
+func closeHandles(handles []*sql.DB) error {
+	var errs []error
+	for _, handle := range handles {
+		if err := handle.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
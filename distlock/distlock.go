@@ -5,8 +5,11 @@ import (
 	"errors"
 	"time"
 
-	"github.com/google/uuid"
 	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+	"github.com/yinqf/go-pkg/redis"
 )
 
 var releaseScript = goredis.NewScript(`
@@ -17,9 +20,12 @@ var releaseScript = goredis.NewScript(`
     end
 `)
 
+type releaseContextKey struct{}
+
 // Do 尝试通过 Redis 分布式锁执行任务。成功获取锁时返回 true。
-func Do(ctx context.Context, client *goredis.Client, key string, ttl time.Duration, task func(context.Context)) (bool, error) {
-	if client == nil {
+// 任务可在临界区结束后调用 Release(ctx) 提前释放锁，任务返回后的兜底释放会因值校验而成为空操作。
+func Do(ctx context.Context, client goredis.UniversalClient, key string, ttl time.Duration, task func(context.Context), opts ...Option) (bool, error) {
+	if redis.IsNilClient(client) {
 		return false, errors.New("redis client is nil")
 	}
 	if ttl <= 0 {
@@ -27,21 +33,75 @@ func Do(ctx context.Context, client *goredis.Client, key string, ttl time.Durati
 		return true, nil
 	}
 
-	lockKey := "lock:" + key
-	lockVal := uuid.NewString()
-
-	ok, err := client.SetNX(ctx, lockKey, lockVal, ttl).Result()
-	if err != nil {
+	l, err := acquire(ctx, client, key, ttl)
+	if err != nil || l == nil {
 		return false, err
 	}
+
+	o := buildOptions(opts)
+	defer releaseAfterTask(ctx, l, o)
+
+	if o.maxHold > 0 {
+		stop := watchHold(l.key, o, l.Release)
+		defer stop()
+	}
+
+	task(context.WithValue(ctx, releaseContextKey{}, l))
+	return true, nil
+}
+
+// Release 在任务内部提前释放当前持有的锁，未持有锁或已释放时为空操作。
+func Release(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	l, ok := ctx.Value(releaseContextKey{}).(*Lock)
 	if !ok {
-		return false, nil
+		return nil
+	}
+	return l.Release(ctx)
+}
+
+// releaseAfterTask 在任务结束后释放锁；锁已不再属于自己时记录警告并触发 WithOnLost 回调，提示 TTL 可能过短。
+func releaseAfterTask(ctx context.Context, l *Lock, o options) {
+	err := l.Release(ctx)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrLockLost):
+		logger.Warn("释放分布式锁时锁已不再持有，可能已过期并被其他实例获取", zap.String("key", l.key))
+		if o.onLost != nil {
+			o.onLost(l.key)
+		}
+	default:
+		logger.Error("释放分布式锁失败", zap.String("key", l.key), zap.Error(err))
 	}
+}
 
-	defer func() {
-		_, _ = releaseScript.Run(ctx, client, []string{lockKey}, lockVal).Result()
-	}()
+// watchHold 在持有时间超过上限时记录警告并按需强制释放，返回的函数在任务结束时调用。
+func watchHold(lockKey string, o options, release func(context.Context) error) func() {
+	start := time.Now()
+	timer := time.AfterFunc(o.maxHold, func() {
+		logger.Warn("分布式锁持有时间超过上限",
+			zap.String("key", lockKey),
+			zap.Duration("held", time.Since(start)),
+			zap.Duration("max_hold", o.maxHold),
+			zap.Bool("force_release", o.forceRelease),
+		)
+		if o.forceRelease {
+			if err := release(context.Background()); err != nil {
+				logger.Error("强制释放分布式锁失败", zap.String("key", lockKey), zap.Error(err))
+			}
+		}
+	})
 
-	task(ctx)
-	return true, nil
+	return func() {
+		if timer.Stop() {
+			return
+		}
+		logger.Warn("分布式锁任务结束，持有时间超过上限",
+			zap.String("key", lockKey),
+			zap.Duration("held", time.Since(start)),
+			zap.Duration("max_hold", o.maxHold),
+		)
+	}
 }
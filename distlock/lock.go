@@ -0,0 +1,95 @@
+package distlock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/yinqf/go-pkg/redis"
+)
+
+// ErrNotAcquired 表示锁已被其他持有者占用。
+var ErrNotAcquired = errors.New("lock not acquired")
+
+// Lock 为已获取的分布式锁句柄，可在不同作用域中释放或续期，适用于获取与释放跨越请求与异步任务的场景。
+type Lock struct {
+	client goredis.UniversalClient
+	key    string
+	value  string
+
+	releaseOnce sync.Once
+	releaseErr  error
+	// released 在调用 Release 后置位，续期协程据此停止续期。
+	released atomic.Bool
+}
+
+// Acquire 尝试获取锁，锁已被占用时返回 ErrNotAcquired。
+func Acquire(ctx context.Context, client goredis.UniversalClient, key string, ttl time.Duration) (*Lock, error) {
+	if redis.IsNilClient(client) {
+		return nil, errors.New("redis client is nil")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("lock ttl must be positive")
+	}
+
+	l, err := acquire(ctx, client, key, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, ErrNotAcquired
+	}
+	return l, nil
+}
+
+// acquire 通过 SetNX 获取锁，锁已被占用时返回 nil。
+func acquire(ctx context.Context, client goredis.UniversalClient, key string, ttl time.Duration) (*Lock, error) {
+	lockKey := redis.Key("lock", key)
+	lockVal := uuid.NewString()
+
+	ok, err := client.SetNX(ctx, lockKey, lockVal, ttl).Result()
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &Lock{client: client, key: lockKey, value: lockVal}, nil
+}
+
+// Key 返回带命名空间前缀的完整锁键。
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Release 通过值校验删除锁，锁已过期或被他人持有时返回 ErrLockLost；重复调用只会执行一次并返回首次的结果。
+func (l *Lock) Release(ctx context.Context) error {
+	l.releaseOnce.Do(func() {
+		l.released.Store(true)
+		deleted, err := releaseScript.Run(ctx, l.client, []string{l.key}, l.value).Int64()
+		switch {
+		case err != nil:
+			l.releaseErr = err
+		case deleted == 0:
+			l.releaseErr = ErrLockLost
+		}
+	})
+	return l.releaseErr
+}
+
+// Extend 将锁的 TTL 重置为 ttl，锁已过期或被他人持有时返回 ErrLockLost。
+func (l *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("lock ttl must be positive")
+	}
+	renewed, err := renewScript.Run(ctx, l.client, []string{l.key}, l.value, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if renewed == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package distlock
+
+import "time"
+
+// Option 用于定制 Do 的行为。
+type Option func(*options)
+
+type options struct {
+	maxHold      time.Duration
+	forceRelease bool
+	onLost       func(key string)
+}
+
+// WithMaxHold 设置锁的最长持有时间，与 TTL 相互独立；超过时以 Warn 记录锁键与已持有时长，便于告警。
+// forceRelease 为 true 时同时强制释放锁，让其他实例可以获取，此时任务需自行保证可被并发执行。
+func WithMaxHold(d time.Duration, forceRelease bool) Option {
+	return func(o *options) {
+		o.maxHold = d
+		o.forceRelease = forceRelease
+	}
+}
+
+// WithOnLost 设置任务结束释放锁时发现锁已不再属于自己（已过期并可能被其他实例获取）的回调，参数为完整锁键。
+func WithOnLost(fn func(key string)) Option {
+	return func(o *options) {
+		o.onLost = fn
+	}
+}
+
+func buildOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
@@ -0,0 +1,94 @@
+package distlock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+	"github.com/yinqf/go-pkg/redis"
+)
+
+type reentrantTokenKey struct{}
+
+// reentrantAcquireScript 在锁不存在或已由同一令牌持有时递增引用计数并刷新 TTL。
+var reentrantAcquireScript = goredis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 or redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
+        redis.call("HINCRBY", KEYS[1], ARGV[1], 1)
+        redis.call("PEXPIRE", KEYS[1], ARGV[2])
+        return 1
+    else
+        return 0
+    end
+`)
+
+// reentrantReleaseScript 递减引用计数，归零时删除锁；令牌不再持有锁时返回 -1。
+var reentrantReleaseScript = goredis.NewScript(`
+    if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 0 then
+        return -1
+    end
+    local count = redis.call("HINCRBY", KEYS[1], ARGV[1], -1)
+    if count <= 0 then
+        redis.call("DEL", KEYS[1])
+        return 0
+    end
+    return count
+`)
+
+// WithReentrantToken 返回携带可重入令牌的上下文。持有相同令牌的 DoReentrant 调用可重复获取同一把锁。
+// 令牌随 context 传递而非绑定 goroutine：把该上下文交给其他 goroutine 时，它们也被视为同一持有者，需自行保证不会并发进入临界区。
+func WithReentrantToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, reentrantTokenKey{}, token)
+}
+
+// ReentrantToken 返回上下文中的可重入令牌。
+func ReentrantToken(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	token, ok := ctx.Value(reentrantTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// DoReentrant 以可重入方式获取锁并执行任务，成功获取时返回 true。
+// 锁以 Redis 哈希保存各令牌的引用计数，令牌取自 ctx（见 WithReentrantToken），缺省时生成新令牌并注入任务上下文，
+// 因此任务内使用该上下文对同一 key 嵌套调用 DoReentrant 会直接重入；每次重入都会把 TTL 刷新为 ttl，计数归零时才删除锁。
+// 同一 key 不能与 Do、Acquire 等非重入锁混用。
+func DoReentrant(ctx context.Context, client goredis.UniversalClient, key string, ttl time.Duration, task func(context.Context)) (bool, error) {
+	if redis.IsNilClient(client) {
+		return false, errors.New("redis client is nil")
+	}
+	if ttl <= 0 {
+		task(ctx)
+		return true, nil
+	}
+
+	token, ok := ReentrantToken(ctx)
+	if !ok {
+		token = uuid.NewString()
+		ctx = WithReentrantToken(ctx, token)
+	}
+
+	lockKey := redis.Key("lock", key)
+	acquired, err := reentrantAcquireScript.Run(ctx, client, []string{lockKey}, token, ttl.Milliseconds()).Int64()
+	if err != nil || acquired == 0 {
+		return false, err
+	}
+
+	defer func() {
+		remaining, err := reentrantReleaseScript.Run(ctx, client, []string{lockKey}, token).Int64()
+		switch {
+		case err != nil:
+			logger.Error("释放可重入分布式锁失败", zap.String("key", lockKey), zap.Error(err))
+		case remaining < 0:
+			logger.Warn("释放可重入分布式锁时锁已不再持有，可能已过期并被其他实例获取", zap.String("key", lockKey))
+		}
+	}()
+
+	task(ctx)
+	return true, nil
+}
@@ -0,0 +1,98 @@
+package distlock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+	"github.com/yinqf/go-pkg/redis"
+)
+
+// ErrLockLost 表示续期时发现锁已过期或被其他持有者占用。
+var ErrLockLost = errors.New("lock lost")
+
+var renewScript = goredis.NewScript(`
+    if redis.call("GET", KEYS[1]) == ARGV[1] then
+        return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+    else
+        return 0
+    end
+`)
+
+// DoWithRenewal 与 Do 相同，但在任务执行期间每隔 renewEvery 将锁的 TTL 续期为 ttl，任务结束后停止续期。
+// renewEvery <= 0 时取 ttl/3；续期失败时取消传给任务的上下文，任务应据此尽快退出。
+func DoWithRenewal(ctx context.Context, client goredis.UniversalClient, key string, ttl, renewEvery time.Duration, task func(context.Context), opts ...Option) (bool, error) {
+	if redis.IsNilClient(client) {
+		return false, errors.New("redis client is nil")
+	}
+	if ttl <= 0 {
+		task(ctx)
+		return true, nil
+	}
+	if renewEvery <= 0 || renewEvery >= ttl {
+		renewEvery = ttl / 3
+	}
+
+	l, err := acquire(ctx, client, key, ttl)
+	if err != nil || l == nil {
+		return false, err
+	}
+
+	o := buildOptions(opts)
+	defer releaseAfterTask(ctx, l, o)
+
+	if o.maxHold > 0 {
+		stop := watchHold(l.key, o, l.Release)
+		defer stop()
+	}
+
+	taskCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		renewLoop(taskCtx, l, ttl, renewEvery, done, cancel)
+	}()
+
+	task(context.WithValue(taskCtx, releaseContextKey{}, l))
+	close(done)
+	<-stopped
+	return true, nil
+}
+
+// renewLoop 周期性续期锁，直到任务结束、任务通过 Release 提前释放锁或续期失败。
+func renewLoop(ctx context.Context, l *Lock, ttl, every time.Duration, done <-chan struct{}, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if l.released.Load() {
+				return
+			}
+			err := l.Extend(ctx, ttl)
+			if err == nil {
+				continue
+			}
+			// 续期与提前释放并发时，释放导致的 ErrLockLost 不视为失败。
+			if l.released.Load() {
+				return
+			}
+			logger.Error("分布式锁续期失败", zap.String("key", l.key), zap.Error(err))
+			cancel(fmt.Errorf("renew lock %s: %w", l.key, err))
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package distlock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/yinqf/go-pkg/redis"
+)
+
+// ErrWaitTimeout 表示在等待时间内未能获取到锁。
+var ErrWaitTimeout = errors.New("wait for lock timeout")
+
+const defaultRetryInterval = 100 * time.Millisecond
+
+// DoWithWait 在锁被占用时每隔 retryInterval 重试，直到获取成功后执行任务并释放锁。
+// 超过 waitTimeout 仍未获取时返回 ErrWaitTimeout，ctx 取消时返回 ctx.Err()；retryInterval <= 0 时使用 100ms。
+func DoWithWait(ctx context.Context, client goredis.UniversalClient, key string, ttl, waitTimeout, retryInterval time.Duration, task func(context.Context), opts ...Option) error {
+	if redis.IsNilClient(client) {
+		return errors.New("redis client is nil")
+	}
+	if ttl <= 0 {
+		task(ctx)
+		return nil
+	}
+
+	l, err := acquireWithWait(ctx, client, key, ttl, waitTimeout, retryInterval)
+	if err != nil {
+		return err
+	}
+
+	o := buildOptions(opts)
+	defer releaseAfterTask(ctx, l, o)
+
+	if o.maxHold > 0 {
+		stop := watchHold(l.key, o, l.Release)
+		defer stop()
+	}
+
+	task(context.WithValue(ctx, releaseContextKey{}, l))
+	return nil
+}
+
+// acquireWithWait 轮询获取锁，直到成功、等待超时或 ctx 取消。
+func acquireWithWait(ctx context.Context, client goredis.UniversalClient, key string, ttl, waitTimeout, retryInterval time.Duration) (*Lock, error) {
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+	deadline := time.Now().Add(waitTimeout)
+
+	for {
+		l, err := acquire(ctx, client, key, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if l != nil {
+			return l, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, ErrWaitTimeout
+		}
+
+		timer := time.NewTimer(min(retryInterval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
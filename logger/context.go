@@ -0,0 +1,48 @@
+package logger
+
+import "context"
+
+type contextKey string
+
+// requestIDContextKey 作为 context.WithValue 的 key，保存当前请求的 request id。
+const requestIDContextKey contextKey = "github.com/yinqf/go-pkg/logger/request_id"
+
+// RequestIDHeader 为传递请求 ID 的 HTTP 头。
+const RequestIDHeader = "X-Request-ID"
+
+// ContextWithRequestID 将 request id 存入上下文，便于数据库等下游日志与访问日志关联。
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestIDFromContext 从上下文中提取 request id，若不存在返回 false。
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	requestID, ok := ctx.Value(requestIDContextKey).(string)
+	return requestID, ok && requestID != ""
+}
+
+// traceIDContextKey 作为 context.WithValue 的 key，保存链路追踪的 trace id。
+const traceIDContextKey contextKey = "github.com/yinqf/go-pkg/logger/trace_id"
+
+// ContextWithTraceID 将 trace id 存入上下文，InfoCtx 等函数会自动附加 trace_id 字段。
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, traceIDContextKey, traceID)
+}
+
+// TraceIDFromContext 从上下文中提取 trace id，若不存在返回 false。
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	traceID, ok := ctx.Value(traceIDContextKey).(string)
+	return traceID, ok && traceID != ""
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ContextExtractor 从上下文中提取需要附加到日志的字段，不存在时返回 nil。
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = []ContextExtractor{requestIDFields, traceIDFields}
+)
+
+// RegisterContextExtractor 注册上下文字段提取器，InfoCtx 等函数会依次调用全部提取器；
+// 应在初始化阶段调用，例如 auth 包在 init 中注册 subject 提取器，避免 logger 反向依赖业务包。
+func RegisterContextExtractor(fn ContextExtractor) {
+	if fn == nil {
+		return
+	}
+	extractorsMu.Lock()
+	extractors = append(extractors, fn)
+	extractorsMu.Unlock()
+}
+
+func requestIDFields(ctx context.Context) []zap.Field {
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		return []zap.Field{zap.String("request_id", requestID)}
+	}
+	return nil
+}
+
+func traceIDFields(ctx context.Context) []zap.Field {
+	if traceID, ok := TraceIDFromContext(ctx); ok {
+		return []zap.Field{zap.String("trace_id", traceID)}
+	}
+	return nil
+}
+
+// contextFields 将提取器得到的字段放在调用方字段之前。
+func contextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	if ctx == nil {
+		return fields
+	}
+
+	extractorsMu.RLock()
+	registered := extractors
+	extractorsMu.RUnlock()
+
+	var extracted []zap.Field
+	for _, extract := range registered {
+		extracted = append(extracted, extract(ctx)...)
+	}
+	if len(extracted) == 0 {
+		return fields
+	}
+	return append(extracted, fields...)
+}
+
+// InfoCtx 与 Info 相同，额外附加从 ctx 提取的 request_id、trace_id 等关联字段。
+func InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ensureLoggers().info.Info(msg, contextFields(ctx, fields)...)
+}
+
+// DebugCtx 与 Debug 相同，额外附加从 ctx 提取的关联字段。
+func DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ensureLoggers().debug.Debug(msg, contextFields(ctx, fields)...)
+}
+
+// WarnCtx 与 Warn 相同，额外附加从 ctx 提取的关联字段。
+func WarnCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ensureLoggers().warn.Warn(msg, contextFields(ctx, fields)...)
+}
+
+// ErrorCtx 与 Error 相同，额外附加从 ctx 提取的关联字段。
+func ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	ensureLoggers().error.Error(msg, contextFields(ctx, fields)...)
+}
@@ -1,11 +1,16 @@
 package logger
 
 import (
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,71 +20,349 @@ import (
 const (
 	logDir            = "logs"
 	logRetention      = 7 * 24 * time.Hour
-	logMaxSize        = 100 * 1024 * 1024 // 100 MB
+	logMaxSize        = 100 * 1024 * 1024       // 100 MB
+	logMaxTotalSize   = 20 * 1024 * 1024 * 1024 // 20 GB，每个级别的默认磁盘预算
 	logDateLayout     = "2006-01-02"
+	logHourLayout     = "2006-01-02-15"
 	consoleTimeLayout = "2006-01-02 15:04:05,000"
 )
 
+// loggerSet 保存按级别拆分的 logger 及其文件写入器。
+type loggerSet struct {
+	info    *zap.Logger
+	debug   *zap.Logger
+	warn    *zap.Logger
+	error   *zap.Logger
+	writers []*rotatingWriter
+}
+
 var (
-	once        sync.Once
-	infoLogger  *zap.Logger
-	debugLogger *zap.Logger
-	errorLogger *zap.Logger
+	stateMu sync.Mutex
+	current atomic.Pointer[loggerSet]
 )
 
-func ensureLoggers() {
-	once.Do(func() {
+// ensureLoggers 按当前选项懒加载 logger；Configure 或 ResetForTest 之后会重新构建。
+func ensureLoggers() *loggerSet {
+	if set := current.Load(); set != nil {
+		return set
+	}
+
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	if set := current.Load(); set != nil {
+		return set
+	}
+
+	set := buildLoggers(currentOptions())
+	current.Store(set)
+	return set
+}
+
+func buildLoggers(opts Options) *loggerSet {
+	if !opts.StdoutOnly {
 		if err := os.MkdirAll(logDir, 0o755); err != nil {
 			panic("create log directory: " + err.Error())
 		}
+	}
 
-		infoLogger = newLevelLogger("info", zapcore.InfoLevel)
-		debugLogger = newLevelLogger("debug", zapcore.DebugLevel)
-		errorLogger = newLevelLogger("error", zapcore.ErrorLevel)
-	})
+	set := &loggerSet{}
+	var writer *rotatingWriter
+	set.info, writer = newLevelLogger("info", zapcore.InfoLevel, opts)
+	set.addWriter(writer)
+	set.debug, writer = newLevelLogger("debug", zapcore.DebugLevel, opts)
+	set.addWriter(writer)
+	set.warn, writer = newLevelLogger("warn", zapcore.WarnLevel, opts)
+	set.addWriter(writer)
+	set.error, writer = newLevelLogger("error", zapcore.ErrorLevel, opts)
+	set.addWriter(writer)
+	return set
 }
 
-func newLevelLogger(levelName string, level zapcore.Level) *zap.Logger {
-	writer := newRotatingWriter(levelName)
+func (s *loggerSet) addWriter(writer *rotatingWriter) {
+	if writer != nil {
+		s.writers = append(s.writers, writer)
+	}
+}
 
-	levelFilter := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return l == level })
-	fileEncoder := zapcore.NewConsoleEncoder(newHumanEncoderConfig())
-	fileCore := zapcore.NewCore(
-		fileEncoder,
-		zapcore.AddSync(writer),
-		levelFilter,
-	)
+// close 刷新并关闭该组 logger 持有的文件。
+// 标准输出在部分平台上不支持 fsync，因此忽略 zap 层面的 Sync 错误，仅返回文件刷新与关闭的错误。
+func (s *loggerSet) close() error {
+	for _, l := range []*zap.Logger{s.info, s.debug, s.warn, s.error} {
+		_ = l.Sync()
+	}
 
+	var errs []error
+	for _, writer := range s.writers {
+		if err := writer.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := writer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// waitCleanups 等待该组 writer 的后台清理结束。
+func (s *loggerSet) waitCleanups() {
+	for _, writer := range s.writers {
+		writer.cleanups.Wait()
+	}
+}
+
+// resetLoggers 丢弃已构建的 logger，下一次写日志时按最新选项重新构建。
+// 清理协程出错时会经 ensureLoggers 记录日志，因此须在释放 stateMu 之后再等待其结束，否则会互相等待。
+func resetLoggers() error {
+	stateMu.Lock()
+	set := current.Swap(nil)
+	var err error
+	if set != nil {
+		err = set.close()
+	}
+	stateMu.Unlock()
+
+	if set != nil {
+		set.waitCleanups()
+	}
+	return err
+}
+
+// Close 刷新所有级别的日志、关闭文件并等待后台清理结束，建议在 main 中 defer 调用。
+// 可重复调用，未初始化时直接返回；之后再写日志会重新初始化。
+func Close() error {
+	return resetLoggers()
+}
+
+// newLevelLogger 构建单个级别的 logger；AddCallerSkip(1) 跳过包级函数与 ScopedLogger 方法这一层，更深的封装通过 CallerSkip 追加。
+func newLevelLogger(levelName string, level zapcore.Level, opts Options) (*zap.Logger, *rotatingWriter) {
+	levelFilter := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return l == level })
 	consoleEncoder := zapcore.NewConsoleEncoder(newHumanEncoderConfig())
+	// 仅标准输出时 stdout 是唯一出口，直接使用配置的格式；否则需显式设置 StdoutJSON。
+	if opts.Format == FormatJSON && (opts.StdoutJSON || opts.StdoutOnly) {
+		consoleEncoder = zapcore.NewJSONEncoder(newJSONEncoderConfig())
+	}
 	consoleCore := zapcore.NewCore(
 		consoleEncoder,
 		zapcore.Lock(os.Stdout),
 		levelFilter,
 	)
 
+	if opts.StdoutOnly {
+		return zap.New(consoleCore, zap.AddCaller(), zap.AddCallerSkip(1)), nil
+	}
+
+	writer := newRotatingWriter(levelName, opts.MaxTotalSize, opts.RotationInterval)
+	writer.compress = opts.Compress
+	writer.writeTimeout = opts.WriteTimeout
+	fileEncoder := zapcore.NewConsoleEncoder(newHumanEncoderConfig())
+	if opts.Format == FormatJSON {
+		fileEncoder = zapcore.NewJSONEncoder(newJSONEncoderConfig())
+	}
+	fileCore := zapcore.NewCore(
+		fileEncoder,
+		zapcore.AddSync(writer),
+		levelFilter,
+	)
+
 	tee := zapcore.NewTee(fileCore, consoleCore)
-	return zap.New(tee, zap.AddCaller(), zap.AddCallerSkip(1))
+	return zap.New(tee, zap.AddCaller(), zap.AddCallerSkip(1)), writer
+}
+
+// closeWaitTimeout 为 Close 等待进行中写入的最长时间，超时后直接关闭文件以中断阻塞的写入。
+const closeWaitTimeout = time.Second
+
+// errWriterBusy 表示写入器被阻塞的写入占用。
+var errWriterBusy = errors.New("log writer is busy")
+
+// writeQueueSize 为设置 WriteTimeout 时异步写入队列的容量，队列满且等待超时的日志改写到标准错误。
+const writeQueueSize = 1024
+
+// queuedWrite 为排队等待写入文件的一条日志；flushed 非空时表示刷新标记，写入器处理到该项时关闭它。
+type queuedWrite struct {
+	now     time.Time
+	p       []byte
+	flushed chan struct{}
 }
 
 type rotatingWriter struct {
-	mu           sync.Mutex
+	// sem 为容量为 1 的信号量，替代互斥锁以支持带超时的获取。
+	sem          chan struct{}
+	active       atomic.Pointer[os.File]
+	writeTimeout time.Duration
+	queue        chan queuedWrite
+	startQueue   sync.Once
+	stop         chan struct{}
+	closed       atomic.Bool
+	cleanups     sync.WaitGroup
 	level        string
+	maxTotalSize int64
+	interval     RotationInterval
+	compress     bool
+	path         string
+	now          func() time.Time
 	currentDate  string
 	currentIndex int
 	currentSize  int64
-	file         *os.File
+	file         logFileWriter
 	nextRotation time.Time
 }
 
-func newRotatingWriter(level string) *rotatingWriter {
-	return &rotatingWriter{level: level}
+// logFileWriter 为 rotatingWriter 写入的目标文件，测试中可替换为模拟实现。
+type logFileWriter interface {
+	io.Writer
+	Sync() error
+	Close() error
+}
+
+func newRotatingWriter(level string, maxTotalSize int64, interval RotationInterval) *rotatingWriter {
+	return newRotatingWriterWithClock(level, maxTotalSize, interval, time.Now)
+}
+
+// newRotatingWriterWithClock 允许注入时钟，便于测试跨天、跨小时的滚动逻辑。
+func newRotatingWriterWithClock(level string, maxTotalSize int64, interval RotationInterval, now func() time.Time) *rotatingWriter {
+	if maxTotalSize <= 0 {
+		maxTotalSize = logMaxTotalSize
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &rotatingWriter{sem: make(chan struct{}, 1), stop: make(chan struct{}), level: level, maxTotalSize: maxTotalSize, interval: interval, now: now}
+}
+
+// lock 获取写入器，timeout<=0 时一直等待；超时返回 false。
+func (w *rotatingWriter) lock(timeout time.Duration) bool {
+	if timeout <= 0 {
+		w.sem <- struct{}{}
+		return true
+	}
+	select {
+	case w.sem <- struct{}{}:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case w.sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (w *rotatingWriter) unlock() {
+	<-w.sem
+}
+
+// fallback 在文件写入阻塞超时时改写到标准错误，避免磁盘挂起拖住整个应用的日志。
+func (w *rotatingWriter) fallback(p []byte) (int, error) {
+	_, _ = os.Stderr.Write(p)
+	return len(p), nil
 }
 
+// Write 写入日志文件。设置了 WriteTimeout 时，日志复制后交给单个后台协程按顺序写入有界队列，
+// 队列已满且等待超过 WriteTimeout 时该条日志改写到标准错误，避免磁盘挂起拖住整个应用。
 func (w *rotatingWriter) Write(p []byte) (int, error) {
-	now := time.Now()
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	if w.closed.Load() {
+		return w.fallback(p)
+	}
+	now := w.now()
+	if w.writeTimeout <= 0 {
+		w.lock(0)
+		defer w.unlock()
+		return w.writeLocked(now, p)
+	}
+
+	// zap 会在 Write 返回后复用 p，入队前必须复制。
+	buf := make([]byte, len(p))
+	copy(buf, p)
+	if !w.enqueue(queuedWrite{now: now, p: buf}) {
+		return w.fallback(p)
+	}
+	return len(p), nil
+}
+
+// enqueue 将写入项放入队列，必要时启动后台写入协程；在 WriteTimeout 内无法入队时返回 false。
+func (w *rotatingWriter) enqueue(item queuedWrite) bool {
+	w.startQueue.Do(func() {
+		w.queue = make(chan queuedWrite, writeQueueSize)
+		go w.drain()
+	})
+
+	select {
+	case w.queue <- item:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(w.writeTimeout)
+	defer timer.Stop()
+	select {
+	case w.queue <- item:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// drain 按顺序写入队列中的日志，写入失败的日志改写到标准错误；Close 后退出，剩余日志改写到标准错误。
+func (w *rotatingWriter) drain() {
+	for {
+		select {
+		case item := <-w.queue:
+			w.process(item)
+		case <-w.stop:
+			for {
+				select {
+				case item := <-w.queue:
+					w.process(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *rotatingWriter) process(item queuedWrite) {
+	if item.flushed != nil {
+		close(item.flushed)
+		return
+	}
+	w.lock(0)
+	_, err := w.writeLocked(item.now, item.p)
+	w.unlock()
+	if err != nil {
+		_, _ = w.fallback(item.p)
+	}
+}
+
+// flushQueue 等待此前入队的日志全部写完，超过 timeout 返回 errWriterBusy；未启用队列时直接返回。
+func (w *rotatingWriter) flushQueue(timeout time.Duration) error {
+	if w.writeTimeout <= 0 {
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	if !w.enqueue(queuedWrite{flushed: flushed}) {
+		return errWriterBusy
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-flushed:
+		return nil
+	case <-timer.C:
+		return errWriterBusy
+	}
+}
 
+func (w *rotatingWriter) writeLocked(now time.Time, p []byte) (int, error) {
+	// 已关闭的写入器不再重新打开文件，避免 Close 之后仍持有该写入器的协程泄漏文件句柄。
+	if w.closed.Load() {
+		return w.fallback(p)
+	}
 	if err := w.ensureFile(now); err != nil {
 		return 0, err
 	}
@@ -90,14 +373,32 @@ func (w *rotatingWriter) Write(p []byte) (int, error) {
 		}
 	}
 
-	n, err := w.file.Write(p)
-	w.currentSize += int64(n)
-	return n, err
+	written := 0
+	for written < len(p) {
+		n, err := w.file.Write(p[written:])
+		written += n
+		w.currentSize += int64(n)
+		if err != nil {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
 }
 
 func (w *rotatingWriter) Sync() error {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	if w.closed.Load() {
+		return nil
+	}
+	if err := w.flushQueue(w.writeTimeout); err != nil {
+		return err
+	}
+	if !w.lock(w.writeTimeout) {
+		return errWriterBusy
+	}
+	defer w.unlock()
 
 	if w.file == nil {
 		return nil
@@ -106,13 +407,47 @@ func (w *rotatingWriter) Sync() error {
 	return w.file.Sync()
 }
 
+// Close 写完已排队的日志后关闭当前文件，之后的写入改写到标准错误而不会重新打开文件；重复调用直接返回。
+// 若有写入阻塞超过 closeWaitTimeout，则直接关闭活动文件以中断该写入。
+func (w *rotatingWriter) Close() error {
+	if w.closed.Load() {
+		return nil
+	}
+	wait := w.writeTimeout
+	if wait < closeWaitTimeout {
+		wait = closeWaitTimeout
+	}
+	_ = w.flushQueue(wait)
+	if w.closed.Swap(true) {
+		return nil
+	}
+	close(w.stop)
+
+	if !w.lock(wait) {
+		if file := w.active.Load(); file != nil {
+			return file.Close()
+		}
+		return errWriterBusy
+	}
+	defer w.unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	w.active.Store(nil)
+	return err
+}
+
 func (w *rotatingWriter) ensureFile(now time.Time) error {
 	if w.file == nil {
-		return w.openFile(now.Format(logDateLayout), 0, now)
+		return w.openFile(w.period(now), 0, now)
 	}
 
 	if now.After(w.nextRotation) {
-		return w.openFile(now.Format(logDateLayout), 0, now)
+		return w.openFile(w.period(now), 0, now)
 	}
 
 	return nil
@@ -123,11 +458,14 @@ func (w *rotatingWriter) rotate(now time.Time) error {
 }
 
 func (w *rotatingWriter) openFile(date string, index int, now time.Time) error {
+	filename := w.buildFilename(date, index)
 	if w.file != nil {
 		_ = w.file.Close()
+		if w.compress && w.path != "" && w.path != filename {
+			go compressFile(w.path)
+		}
 	}
 
-	filename := w.buildFilename(date, index)
 	file, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
 	if err != nil {
 		return err
@@ -140,14 +478,14 @@ func (w *rotatingWriter) openFile(date string, index int, now time.Time) error {
 	}
 
 	w.file = file
+	w.active.Store(file)
+	w.path = filename
 	w.currentDate = date
 	w.currentIndex = index
 	w.currentSize = info.Size()
-	w.nextRotation = startOfNextDay(now)
+	w.nextRotation = w.nextBoundary(now)
 
-	if index == 0 {
-		w.scheduleCleanup()
-	}
+	w.scheduleCleanup(filename)
 	return nil
 }
 
@@ -159,6 +497,22 @@ func (w *rotatingWriter) buildFilename(date string, index int) string {
 	return filepath.Join(logDir, name+".log")
 }
 
+// period 返回文件名中的时间段，按小时滚动时包含小时。
+func (w *rotatingWriter) period(now time.Time) string {
+	if w.interval == Hourly {
+		return now.Format(logHourLayout)
+	}
+	return now.Format(logDateLayout)
+}
+
+func (w *rotatingWriter) nextBoundary(now time.Time) time.Time {
+	if w.interval == Hourly {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, now.Hour(), 0, 0, 0, now.Location()).Add(time.Hour)
+	}
+	return startOfNextDay(now)
+}
+
 func startOfNextDay(t time.Time) time.Time {
 	y, m, d := t.Date()
 	return time.Date(y, m, d, 0, 0, 0, 0, t.Location()).Add(24 * time.Hour)
@@ -182,10 +536,22 @@ func newHumanEncoderConfig() zapcore.EncoderConfig {
 	}
 }
 
-func (w *rotatingWriter) scheduleCleanup() {
-	cutoff := time.Now().Add(-logRetention)
+// newJSONEncoderConfig 与控制台格式保持相同的毫秒级时间精度。
+func newJSONEncoderConfig() zapcore.EncoderConfig {
+	cfg := newHumanEncoderConfig()
+	cfg.EncodeCaller = zapcore.ShortCallerEncoder
+	return cfg
+}
+
+// scheduleCleanup 在后台清理当前级别的日志：先删除超过保留期的文件，
+// 若剩余文件总大小仍超过磁盘预算，再按修改时间从旧到新删除，当前写入的文件不会被删除。
+func (w *rotatingWriter) scheduleCleanup(activePath string) {
+	cutoff := w.now().Add(-logRetention)
 	level := w.level
+	budget := w.maxTotalSize
+	w.cleanups.Add(1)
 	go func() {
+		defer w.cleanups.Done()
 		entries, err := os.ReadDir(logDir)
 		if err != nil {
 			Error("扫描日志目录失败", zap.Error(err))
@@ -193,13 +559,15 @@ func (w *rotatingWriter) scheduleCleanup() {
 		}
 
 		prefix := level + "-"
+		remaining := make([]logFile, 0, len(entries))
+		var total int64
 		for _, entry := range entries {
 			if entry.IsDir() {
 				continue
 			}
 
 			name := entry.Name()
-			if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".log") {
+			if !strings.HasPrefix(name, prefix) || !isLogFile(name) {
 				continue
 			}
 
@@ -208,16 +576,111 @@ func (w *rotatingWriter) scheduleCleanup() {
 				continue
 			}
 
+			path := filepath.Join(logDir, name)
 			if info.ModTime().Before(cutoff) {
-				path := filepath.Join(logDir, name)
 				if err := os.Remove(path); err != nil {
 					Error("删除过期日志失败", zap.String("path", path), zap.Error(err))
 				}
+				continue
 			}
+
+			remaining = append(remaining, logFile{path: path, size: info.Size(), modTime: info.ModTime()})
+			total += info.Size()
+		}
+
+		if total <= budget {
+			return
+		}
+
+		sort.Slice(remaining, func(i, j int) bool { return remaining[i].modTime.Before(remaining[j].modTime) })
+		for _, file := range remaining {
+			if total <= budget {
+				break
+			}
+			if file.path == activePath {
+				continue
+			}
+			if err := os.Remove(file.path); err != nil {
+				Error("删除超出磁盘预算的日志失败", zap.String("path", file.path), zap.Error(err))
+				continue
+			}
+			total -= file.size
 		}
 	}()
 }
 
+func isLogFile(name string) bool {
+	return strings.HasSuffix(name, ".log") || strings.HasSuffix(name, ".log.gz")
+}
+
+// compressFile 将已滚动的日志压缩为 .log.gz 并删除原文件，失败时保留原文件。
+func compressFile(path string) {
+	dst, err := gzipFile(path)
+	if err != nil {
+		Error("压缩日志失败", zap.String("path", path), zap.Error(err))
+		return
+	}
+	if err := os.Remove(path); err != nil {
+		Error("删除已压缩的日志失败", zap.String("path", path), zap.String("archive", dst), zap.Error(err))
+	}
+}
+
+// maxArchiveSuffix 为同名归档已存在时尝试的最大序号。
+const maxArchiveSuffix = 1000
+
+// createArchive 以 O_EXCL 创建归档文件，同名归档已存在（如同一天重启后再次滚动）时依次尝试 name.1.log.gz、name.2.log.gz 等。
+func createArchive(src string) (string, *os.File, error) {
+	base := strings.TrimSuffix(src, ".log")
+	for i := 0; i < maxArchiveSuffix; i++ {
+		dst := src + ".gz"
+		if i > 0 {
+			dst = fmt.Sprintf("%s.%d.log.gz", base, i)
+		}
+		out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			return dst, out, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", nil, err
+		}
+	}
+	return "", nil, fmt.Errorf("no free archive name for %s", src)
+}
+
+// gzipFile 将 src 压缩到新建的归档文件并返回其路径，失败时删除本次创建的归档。
+func gzipFile(src string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	dst, out, err := createArchive(src)
+	if err != nil {
+		return "", err
+	}
+
+	gz := gzip.NewWriter(out)
+	_, err = io.Copy(gz, in)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+		return "", err
+	}
+	return dst, nil
+}
+
+type logFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
 func consoleTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString(t.Format(consoleTimeLayout))
 }
@@ -236,16 +699,17 @@ func consoleCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArray
 }
 
 func Info(msg string, fields ...zap.Field) {
-	ensureLoggers()
-	infoLogger.Info(msg, fields...)
+	ensureLoggers().info.Info(msg, fields...)
 }
 
 func Debug(msg string, fields ...zap.Field) {
-	ensureLoggers()
-	debugLogger.Debug(msg, fields...)
+	ensureLoggers().debug.Debug(msg, fields...)
+}
+
+func Warn(msg string, fields ...zap.Field) {
+	ensureLoggers().warn.Warn(msg, fields...)
 }
 
 func Error(msg string, fields ...zap.Field) {
-	ensureLoggers()
-	errorLogger.Error(msg, fields...)
+	ensureLoggers().error.Error(msg, fields...)
 }
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	// 测试中不创建 logs 目录，包内日志仅输出到标准输出。
+	_ = os.Setenv("LOG_STDOUT_ONLY", "true")
+	os.Exit(m.Run())
+}
+
+// fakeFile 每次最多写入 limit 字节，用于模拟短写。
+type fakeFile struct {
+	buf   bytes.Buffer
+	limit int
+	calls int
+	err   error
+}
+
+func (f *fakeFile) Write(p []byte) (int, error) {
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := len(p)
+	if n > f.limit {
+		n = f.limit
+	}
+	return f.buf.Write(p[:n])
+}
+
+func (f *fakeFile) Sync() error  { return nil }
+func (f *fakeFile) Close() error { return nil }
+
+func newTestWriter(file logFileWriter, now time.Time) *rotatingWriter {
+	w := newRotatingWriterWithClock("info", 0, Daily, func() time.Time { return now })
+	w.file = file
+	w.nextRotation = w.nextBoundary(now)
+	return w
+}
+
+func TestWriteLockedRetriesShortWrites(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	file := &fakeFile{limit: 3}
+	w := newTestWriter(file, now)
+
+	line := []byte("hello rotating writer\n")
+	n, err := w.writeLocked(now, line)
+	if err != nil {
+		t.Fatalf("writeLocked: %v", err)
+	}
+	if n != len(line) {
+		t.Fatalf("n = %d, want %d", n, len(line))
+	}
+	if got := file.buf.String(); got != string(line) {
+		t.Fatalf("written %q, want %q", got, line)
+	}
+	if want := (len(line) + 2) / 3; file.calls != want {
+		t.Fatalf("calls = %d, want %d", file.calls, want)
+	}
+	if w.currentSize != int64(len(line)) {
+		t.Fatalf("currentSize = %d, want %d", w.currentSize, len(line))
+	}
+}
+
+func TestWriteLockedZeroProgress(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	w := newTestWriter(&fakeFile{limit: 0}, now)
+
+	n, err := w.writeLocked(now, []byte("data"))
+	if !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("err = %v, want io.ErrShortWrite", err)
+	}
+	if n != 0 {
+		t.Fatalf("n = %d, want 0", n)
+	}
+}
+
+func TestWriteLockedReturnsWriteError(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	diskFull := errors.New("disk full")
+	w := newTestWriter(&fakeFile{limit: 4, err: diskFull}, now)
+
+	if _, err := w.writeLocked(now, []byte("data")); !errors.Is(err, diskFull) {
+		t.Fatalf("err = %v, want %v", err, diskFull)
+	}
+}
+
+func TestRotatingWriterMidnightRollover(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 23, 59, 59, 0, time.Local)
+	w := newRotatingWriterWithClock("info", 0, Daily, func() time.Time { return now })
+	t.Cleanup(func() {
+		_ = w.Close()
+		w.cleanups.Wait()
+	})
+
+	if _, err := w.Write([]byte("before midnight\n")); err != nil {
+		t.Fatalf("write before midnight: %v", err)
+	}
+	now = now.Add(2 * time.Second)
+	if _, err := w.Write([]byte("after midnight\n")); err != nil {
+		t.Fatalf("write after midnight: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"info-2024-01-01.log": "before midnight\n",
+		"info-2024-01-02.log": "after midnight\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(logDir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Fatalf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
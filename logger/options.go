@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Format 表示日志编码格式。
+type Format int
+
+const (
+	// FormatConsole 为默认的人类可读格式。
+	FormatConsole Format = iota
+	// FormatJSON 输出 JSON 行，便于 Loki、ELK 等系统采集。
+	FormatJSON
+)
+
+// RotationInterval 表示日志文件按时间滚动的粒度。
+type RotationInterval int
+
+const (
+	// Daily 按天滚动，文件名形如 info-2024-01-01.log。
+	Daily RotationInterval = iota
+	// Hourly 按小时滚动，文件名形如 info-2024-01-01-15.log。
+	Hourly
+)
+
+// Options 控制日志的输出方式，需在首次写日志前通过 Configure 设置。
+type Options struct {
+	// StdoutOnly 为 true 时仅输出到标准输出，不创建日志目录、滚动文件与清理协程，适用于 Serverless 等环境。
+	StdoutOnly bool
+	// Format 为日志文件使用的编码格式。
+	Format Format
+	// StdoutJSON 为 true 且 Format 为 FormatJSON 时，标准输出同样使用 JSON 格式；StdoutOnly 模式下无需设置，标准输出直接使用 Format。
+	StdoutJSON bool
+	// MaxTotalSize 为每个级别日志文件的总字节上限，超出时从最旧的文件开始删除；未设置时为 20 GB。
+	MaxTotalSize int64
+	// RotationInterval 为按时间滚动的粒度，默认按天。
+	RotationInterval RotationInterval
+	// Compress 为 true 时，滚动后的旧文件会在后台压缩为 .log.gz。
+	Compress bool
+	// WriteTimeout 大于 0 时日志经有界队列由后台协程写入文件，队列已满且等待超过该时间的日志改写到标准错误；为 0 时同步写入。
+	WriteTimeout time.Duration
+}
+
+var (
+	optionsMu sync.Mutex
+	options   Options
+)
+
+// Configure 设置日志选项；若日志已初始化，会刷新并关闭现有文件，下一条日志按新选项重新构建。
+func Configure(opts Options) {
+	optionsMu.Lock()
+	options = opts
+	optionsMu.Unlock()
+
+	_ = resetLoggers()
+}
+
+// ResetForTest 恢复默认选项并丢弃已构建的 logger，便于测试之间互不干扰。
+func ResetForTest() {
+	Configure(Options{})
+}
+
+// SetFormat 设置日志文件的编码格式；stdout 传入 true 时标准输出也使用相同格式，默认保持控制台格式。
+func SetFormat(format Format, stdout ...bool) {
+	optionsMu.Lock()
+	options.Format = format
+	options.StdoutJSON = len(stdout) > 0 && stdout[0]
+	optionsMu.Unlock()
+
+	_ = resetLoggers()
+}
+
+// currentOptions 返回当前选项，环境变量 LOG_STDOUT_ONLY=true 时同样启用仅标准输出模式。
+func currentOptions() Options {
+	optionsMu.Lock()
+	opts := options
+	optionsMu.Unlock()
+
+	if stdoutOnly, err := strconv.ParseBool(os.Getenv("LOG_STDOUT_ONLY")); err == nil && stdoutOnly {
+		opts.StdoutOnly = true
+	}
+	return opts
+}
+
+// CheckWritable 检查日志目录是否可写，仅标准输出模式下直接返回 nil。
+func CheckWritable() error {
+	if currentOptions().StdoutOnly {
+		return nil
+	}
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(logDir, ".selfcheck-*")
+	if err != nil {
+		return fmt.Errorf("log directory not writable: %w", err)
+	}
+	name := file.Name()
+	_ = file.Close()
+	return os.Remove(name)
+}
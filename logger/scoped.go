@@ -0,0 +1,69 @@
+package logger
+
+import "go.uber.org/zap"
+
+// ScopedLogger 携带一组固定字段，按级别写入与包级函数相同的日志文件。
+type ScopedLogger struct {
+	fields []zap.Field
+	skip   int
+}
+
+// With 返回绑定了 fields 的 ScopedLogger，后续每条日志都会附带这些字段。
+func With(fields ...zap.Field) *ScopedLogger {
+	bound := make([]zap.Field, len(fields))
+	copy(bound, fields)
+	return &ScopedLogger{fields: bound}
+}
+
+// With 在当前字段基础上追加字段并返回新的 ScopedLogger。
+func (l *ScopedLogger) With(fields ...zap.Field) *ScopedLogger {
+	bound := make([]zap.Field, 0, len(l.fields)+len(fields))
+	bound = append(bound, l.fields...)
+	bound = append(bound, fields...)
+	return &ScopedLogger{fields: bound, skip: l.skip}
+}
+
+// CallerSkip 返回额外跳过 skip 层调用栈的 ScopedLogger，供封装日志的辅助函数使用，使日志中的 (file:line) 指向业务代码。
+func CallerSkip(skip int) *ScopedLogger {
+	return With().WithCallerSkip(skip)
+}
+
+// WithCallerSkip 返回在当前基础上额外跳过 skip 层调用栈的 ScopedLogger，字段保持不变。
+func (l *ScopedLogger) WithCallerSkip(skip int) *ScopedLogger {
+	scoped := l.With()
+	scoped.skip += skip
+	return scoped
+}
+
+func (l *ScopedLogger) Info(msg string, fields ...zap.Field) {
+	l.logger(ensureLoggers().info).Info(msg, l.merge(fields)...)
+}
+
+func (l *ScopedLogger) Debug(msg string, fields ...zap.Field) {
+	l.logger(ensureLoggers().debug).Debug(msg, l.merge(fields)...)
+}
+
+func (l *ScopedLogger) Warn(msg string, fields ...zap.Field) {
+	l.logger(ensureLoggers().warn).Warn(msg, l.merge(fields)...)
+}
+
+func (l *ScopedLogger) Error(msg string, fields ...zap.Field) {
+	l.logger(ensureLoggers().error).Error(msg, l.merge(fields)...)
+}
+
+// logger 按调用栈偏移调整 base，未设置偏移时直接复用。
+func (l *ScopedLogger) logger(base *zap.Logger) *zap.Logger {
+	if l.skip == 0 {
+		return base
+	}
+	return base.WithOptions(zap.AddCallerSkip(l.skip))
+}
+
+func (l *ScopedLogger) merge(fields []zap.Field) []zap.Field {
+	if len(l.fields) == 0 {
+		return fields
+	}
+	merged := make([]zap.Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	return append(merged, fields...)
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// captureStdout 在 fn 执行期间把标准输出重定向到管道，返回写入的内容。
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	Configure(Options{StdoutOnly: true})
+	fn()
+	ResetForTest()
+
+	_ = w.Close()
+	return <-done
+}
+
+// logViaHelper 模拟业务中封装日志的辅助函数。
+func logViaHelper(msg string) {
+	CallerSkip(1).Info(msg)
+}
+
+func TestCallerPointsAtCallSite(t *testing.T) {
+	var helperLine, scopedLine int
+	out := captureStdout(t, func() {
+		_, _, line, _ := runtime.Caller(0)
+		logViaHelper("via helper")
+		helperLine = line + 1
+
+		_, _, line, _ = runtime.Caller(0)
+		With().Info("via scoped")
+		scopedLine = line + 1
+	})
+
+	for _, tc := range []struct {
+		msg  string
+		line int
+	}{
+		{"via helper", helperLine},
+		{"via scoped", scopedLine},
+	} {
+		want := fmt.Sprintf("(scoped_test.go:%d)-", tc.line)
+		found := false
+		for _, entry := range strings.Split(out, "\n") {
+			if strings.Contains(entry, tc.msg) {
+				found = true
+				if !strings.Contains(entry, want) {
+					t.Errorf("%q: caller = %q, want %s", tc.msg, entry, want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q not logged; output:\n%s", tc.msg, out)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// NewClusterClient 根据 REDIS_CLUSTER_ADDRS（逗号分隔的 host:port）初始化 Redis Cluster 客户端并验证连通性，
+// 可选的 REDIS_USERNAME、REDIS_PASSWORD 用于认证；集群模式不支持选择 DB。
+func NewClusterClient() (*goredis.ClusterClient, error) {
+	addrs := clusterAddrs()
+	if len(addrs) == 0 {
+		logger.Error("Redis 集群地址为空")
+		return nil, errEmptyClusterAddrs
+	}
+
+	opt := &goredis.ClusterOptions{
+		Addrs:    addrs,
+		Username: os.Getenv("REDIS_USERNAME"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+	}
+	applyPoolEnv(&opt.PoolSize, &opt.MinIdleConns, &opt.ReadTimeout)
+	client := goredis.NewClusterClient(opt)
+
+	timeout := pingTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		logger.Error("Redis 集群连接失败", zap.Strings("addrs", addrs), zap.Duration("timeout", timeout), zap.Error(err))
+		return nil, fmt.Errorf("ping redis cluster: %w", err)
+	}
+
+	logger.Info("Redis 集群客户端已初始化", zap.Strings("addrs", addrs), zap.Int("pool_size", client.Options().PoolSize))
+	return client, nil
+}
+
+// NewUniversalClient 设置了 REDIS_CLUSTER_ADDRS 时返回集群客户端，否则按 REDIS_CONN_STRING 返回单节点客户端。
+func NewUniversalClient() (goredis.UniversalClient, error) {
+	if len(clusterAddrs()) > 0 {
+		return NewClusterClient()
+	}
+	return NewClient()
+}
+
+func clusterAddrs() []string {
+	addrs := make([]string, 0)
+	for _, addr := range strings.Split(os.Getenv("REDIS_CLUSTER_ADDRS"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	keyPrefixOnce sync.Once
+	keyPrefixMu   sync.RWMutex
+	keyPrefix     string
+)
+
+// SetKeyPrefix 覆盖所有辅助函数使用的键命名空间，默认取自环境变量 REDIS_KEY_PREFIX。
+func SetKeyPrefix(prefix string) {
+	keyPrefixOnce.Do(func() {})
+	keyPrefixMu.Lock()
+	defer keyPrefixMu.Unlock()
+	keyPrefix = strings.Trim(strings.TrimSpace(prefix), ":")
+}
+
+// KeyPrefix 返回当前生效的键命名空间。
+func KeyPrefix() string {
+	keyPrefixOnce.Do(func() {
+		keyPrefixMu.Lock()
+		keyPrefix = strings.Trim(strings.TrimSpace(os.Getenv("REDIS_KEY_PREFIX")), ":")
+		keyPrefixMu.Unlock()
+	})
+
+	keyPrefixMu.RLock()
+	defer keyPrefixMu.RUnlock()
+	return keyPrefix
+}
+
+// Key 按 "<prefix>:<part1>:<part2>..." 的格式拼接键名，未配置命名空间时省略前缀。
+func Key(parts ...string) string {
+	segments := make([]string, 0, len(parts)+1)
+	if prefix := KeyPrefix(); prefix != "" {
+		segments = append(segments, prefix)
+	}
+	for _, part := range parts {
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return strings.Join(segments, ":")
+}
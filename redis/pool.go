@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// applyPoolEnv 在解析连接串之后应用 REDIS_POOL_SIZE、REDIS_MIN_IDLE_CONNS 与 REDIS_READ_TIMEOUT，
+// 未设置或格式错误的变量保持 go-redis 默认值。
+func applyPoolEnv(poolSize, minIdleConns *int, readTimeout *time.Duration) {
+	if value, ok := envInt("REDIS_POOL_SIZE"); ok {
+		*poolSize = value
+	}
+	if value, ok := envInt("REDIS_MIN_IDLE_CONNS"); ok {
+		*minIdleConns = value
+	}
+	if raw := os.Getenv("REDIS_READ_TIMEOUT"); raw != "" {
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			logger.Warn("REDIS_READ_TIMEOUT 无效，已忽略", zap.String("value", raw))
+		} else {
+			*readTimeout = value
+		}
+	}
+}
+
+func envInt(key string) (int, bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		logger.Warn("Redis 连接池环境变量无效，已忽略", zap.String("key", key), zap.String("value", raw))
+		return 0, false
+	}
+	return value, true
+}
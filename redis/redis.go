@@ -14,9 +14,13 @@ import (
 )
 
 var (
-	errEmptyConnString = errors.New("redis connection string is empty")
+	errEmptyConnString   = errors.New("redis connection string is empty")
+	errEmptyClusterAddrs = errors.New("redis cluster addrs are empty")
 )
 
+// defaultPingTimeout 为初始化时连通性检查的默认超时时间，可通过 REDIS_PING_TIMEOUT 覆盖。
+const defaultPingTimeout = 3 * time.Second
+
 // NewClient 初始化 Redis 客户端并验证连通性，由依赖注入容器管理其生命周期。
 func NewClient() (*goredis.Client, error) {
 	redisConnString := os.Getenv("REDIS_CONN_STRING")
@@ -31,16 +35,70 @@ func NewClient() (*goredis.Client, error) {
 		return nil, fmt.Errorf("parse redis connection string: %w", err)
 	}
 
+	applyPoolEnv(&opt.PoolSize, &opt.MinIdleConns, &opt.ReadTimeout)
+	return NewClientWithOptions(opt, pingTimeout())
+}
+
+// NewClientWithOptions 使用给定配置初始化 Redis 客户端，并在 timeout 内验证连通性；timeout <= 0 时使用默认的 3 秒。
+func NewClientWithOptions(opt *goredis.Options, timeout time.Duration) (*goredis.Client, error) {
+	if opt == nil {
+		return nil, errors.New("redis options are nil")
+	}
+	if timeout <= 0 {
+		timeout = defaultPingTimeout
+	}
+
 	client := goredis.NewClient(opt)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	if err := client.Ping(ctx).Err(); err != nil {
 		_ = client.Close()
-		logger.Error("Redis 连接失败", zap.Error(err))
+		logger.Error("Redis 连接失败", zap.String("addr", opt.Addr), zap.Duration("timeout", timeout), zap.Error(err))
 		return nil, fmt.Errorf("ping redis: %w", err)
 	}
 
-	logger.Info("Redis 客户端已初始化", zap.String("addr", opt.Addr))
+	logger.Info("Redis 客户端已初始化", zap.String("addr", opt.Addr), zap.Int("pool_size", client.Options().PoolSize))
 	return client, nil
 }
+
+// pingTimeout 读取 REDIS_PING_TIMEOUT（如 500ms、10s），未设置或格式错误时使用默认值。
+func pingTimeout() time.Duration {
+	raw := os.Getenv("REDIS_PING_TIMEOUT")
+	if raw == "" {
+		return defaultPingTimeout
+	}
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		logger.Warn("REDIS_PING_TIMEOUT 无效，使用默认值", zap.String("value", raw), zap.Duration("default", defaultPingTimeout))
+		return defaultPingTimeout
+	}
+	return timeout
+}
+
+// Healthy 通过 PING 检查 Redis 是否可用，单节点与集群客户端均可传入。
+func Healthy(ctx context.Context, client goredis.UniversalClient) error {
+	if IsNilClient(client) {
+		return errors.New("redis client is nil")
+	}
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("ping redis: %w", err)
+	}
+	return nil
+}
+
+// IsNilClient 判断客户端是否为 nil，同时识别包装了 nil 指针的接口值。
+func IsNilClient(client goredis.UniversalClient) bool {
+	switch c := client.(type) {
+	case nil:
+		return true
+	case *goredis.Client:
+		return c == nil
+	case *goredis.ClusterClient:
+		return c == nil
+	case *goredis.Ring:
+		return c == nil
+	default:
+		return false
+	}
+}
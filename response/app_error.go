@@ -0,0 +1,76 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AppError 为带业务错误码的错误，Code 写入响应体，HTTPStatus 作为传输层状态码，两者相互独立。
+type AppError struct {
+	Code       int
+	Message    string
+	HTTPStatus int
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// WithMessage 返回替换了提示信息的副本，错误码与状态码保持不变。
+func (e *AppError) WithMessage(msg string) *AppError {
+	clone := *e
+	clone.Message = msg
+	return &clone
+}
+
+// NewAppError 创建业务错误，httpStatus 非 4xx/5xx 时按 500 处理。
+func NewAppError(code int, httpStatus int, msg string) *AppError {
+	return &AppError{Code: code, Message: msg, HTTPStatus: httpStatus}
+}
+
+// 常用业务错误，错误码稳定，客户端可据此分支处理。
+var (
+	ErrValidation      = NewAppError(40000, http.StatusBadRequest, "参数校验失败")
+	ErrUnauthorized    = NewAppError(40100, http.StatusUnauthorized, "未登录或登录已失效")
+	ErrForbidden       = NewAppError(40300, http.StatusForbidden, "无权访问")
+	ErrNotFound        = NewAppError(40400, http.StatusNotFound, "资源不存在")
+	ErrConflict        = NewAppError(40900, http.StatusConflict, "资源冲突")
+	ErrTooManyRequests = NewAppError(42900, http.StatusTooManyRequests, "请求过于频繁")
+	ErrInternal        = NewAppError(50000, http.StatusInternalServerError, "服务内部错误")
+	ErrUnavailable     = NewAppError(50300, http.StatusServiceUnavailable, "服务暂不可用")
+)
+
+// Fail 按 AppError 输出响应：响应体 code 为业务错误码，HTTP 状态码单独设置。
+// err 不是 AppError 时按 ErrInternal 处理，并在日志中保留原始错误。
+func Fail(c *gin.Context, err error) {
+	FailWithData(c, err, gin.H{})
+}
+
+// FailWithData 与 Fail 相同，额外在响应体 data 中返回错误详情，例如参数校验失败的字段信息。
+func FailWithData(c *gin.Context, err error, data interface{}) {
+	if data == nil {
+		data = gin.H{}
+	}
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr == nil {
+		logFailure(c, ErrInternal.HTTPStatus, ErrInternal.Message, zap.Error(err))
+		write(c, ErrInternal.HTTPStatus, ErrInternal.Code, ErrInternal.Message, gin.H{})
+		return
+	}
+
+	status := appErr.HTTPStatus
+	if status < http.StatusBadRequest {
+		status = http.StatusInternalServerError
+	}
+	msg := appErr.Message
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	logFailure(c, status, msg, zap.Int("code", appErr.Code))
+	write(c, status, appErr.Code, msg, data)
+}
@@ -0,0 +1,71 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// JSONEncoder 将响应体序列化为 JSON。
+//
+// 需要区分"字段为 null"与"字段缺省"时，建议在模型中使用指针字段：
+// 不带 omitempty 的 nil 指针输出为 null，带 omitempty 的 nil 指针不输出该字段；
+// 若仍需更细的控制，可通过 SetJSONEncoder 注入自定义编码器。
+type JSONEncoder func(v interface{}) ([]byte, error)
+
+var (
+	encoderMu sync.RWMutex
+	encoder   JSONEncoder
+	stable    bool
+)
+
+// SetJSONEncoder 设置响应使用的 JSON 编码器，传入 nil 恢复为 gin 默认编码。
+func SetJSONEncoder(fn JSONEncoder) {
+	encoderMu.Lock()
+	defer encoderMu.Unlock()
+	encoder = fn
+}
+
+// SetStableJSON 开启后响应统一使用标准库 encoding/json 序列化，map 类型的 Data（如 gin.H）按键排序，
+// 相同数据总是得到字节一致的响应，便于基于响应哈希的缓存与黄金文件测试；默认关闭。
+// 已通过 SetJSONEncoder 设置自定义编码器时以自定义编码器为准。
+func SetStableJSON(enabled bool) {
+	encoderMu.Lock()
+	defer encoderMu.Unlock()
+	stable = enabled
+}
+
+func currentEncoder() JSONEncoder {
+	encoderMu.RLock()
+	defer encoderMu.RUnlock()
+	if encoder == nil && stable {
+		return json.Marshal
+	}
+	return encoder
+}
+
+func renderJSON(c *gin.Context, status int, body Body) {
+	encode := currentEncoder()
+	if encode == nil {
+		c.JSON(status, body)
+		return
+	}
+
+	payload, err := encode(body)
+	if err != nil {
+		logger.Error("响应序列化失败", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, Body{
+			Code:    http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+			Data:    gin.H{},
+		})
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", payload)
+}
@@ -0,0 +1,161 @@
+package response
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+const defaultGzipMinSize = 1024
+
+// compressedContentTypes 为已压缩的内容类型前缀，再次压缩没有收益。
+var compressedContentTypes = []string{
+	"image/", "video/", "audio/",
+	"application/gzip", "application/zip", "application/x-gzip",
+	"application/x-7z-compressed", "application/x-rar-compressed",
+	"application/octet-stream", "text/event-stream",
+}
+
+// Gzip 返回响应压缩中间件：客户端声明 Accept-Encoding: gzip 且响应体不小于 minSize 字节时压缩，
+// minSize <= 0 时使用 1KB。中间件会缓冲整个响应体，不适用于 SSE 等流式接口。
+func Gzip(minSize int) gin.HandlerFunc {
+	if minSize <= 0 {
+		minSize = defaultGzipMinSize
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+			writer.flush(minSize)
+		}()
+
+		c.Next()
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if strings.EqualFold(encoding, "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter 缓冲响应体，待处理链结束后决定是否压缩。
+type bufferedWriter struct {
+	gin.ResponseWriter
+	buf     bytes.Buffer
+	status  int
+	written bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+	w.written = true
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.buf.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+func (w *bufferedWriter) flush(minSize int) {
+	if !w.written {
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+	body := w.buf.Bytes()
+	if !w.shouldCompress(header, len(body), minSize) {
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(body)
+		return
+	}
+
+	compressed, err := gzipBytes(body)
+	if err != nil {
+		logger.Error("压缩响应失败", zap.Error(err))
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(body)
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(compressed)))
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(compressed)
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		_ = gz.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *bufferedWriter) shouldCompress(header http.Header, size, minSize int) bool {
+	if size < minSize || header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range compressedContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	catalogMu sync.RWMutex
+	catalogs  map[string]map[int]string
+)
+
+// RegisterMessages 注册某种语言下按业务错误码索引的提示信息，重复注册同一语言会合并覆盖。
+// 响应时按 Accept-Language 选择语言，未命中时沿用调用方传入的 msg。
+func RegisterMessages(lang string, m map[int]string) {
+	lang = normalizeLang(lang)
+	if lang == "" || len(m) == 0 {
+		return
+	}
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if catalogs == nil {
+		catalogs = make(map[string]map[int]string)
+	}
+	messages := catalogs[lang]
+	if messages == nil {
+		messages = make(map[int]string, len(m))
+		catalogs[lang] = messages
+	}
+	for code, msg := range m {
+		messages[code] = msg
+	}
+}
+
+// localize 根据 Accept-Language 查找 code 对应的提示信息，依次尝试完整语言标签与主语言，例如 en-US、en。
+func localize(c *gin.Context, code int, msg string) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if len(catalogs) == 0 || c == nil || c.Request == nil {
+		return msg
+	}
+
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(params) == "q=0" {
+			continue
+		}
+		lang := normalizeLang(tag)
+		if lang == "" {
+			continue
+		}
+		if localized, ok := catalogs[lang][code]; ok {
+			return localized
+		}
+		if primary, _, found := strings.Cut(lang, "-"); found {
+			if localized, ok := catalogs[primary][code]; ok {
+				return localized
+			}
+		}
+	}
+	return msg
+}
+
+func normalizeLang(lang string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(lang)), "_", "-")
+}
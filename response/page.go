@@ -0,0 +1,36 @@
+package response
+
+import "github.com/gin-gonic/gin"
+
+// PageData 为分页响应的统一结构。
+type PageData struct {
+	List       interface{} `json:"list"`
+	Page       int         `json:"page"`
+	Size       int         `json:"size"`
+	Total      int64       `json:"total"`
+	TotalPages int64       `json:"total_pages"`
+}
+
+// NewPageData 构建分页响应数据，并根据 total 与 size 计算 total_pages；total 为负数表示总数未知，此时 total_pages 为 -1。
+func NewPageData(items interface{}, page, size int, total int64) PageData {
+	var totalPages int64
+	if total < 0 {
+		// 总数未知（跳过计数）时总页数同样未知。
+		totalPages = -1
+	} else if size > 0 {
+		totalPages = (total + int64(size) - 1) / int64(size)
+	}
+
+	return PageData{
+		List:       items,
+		Page:       page,
+		Size:       size,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// Page 输出统一的分页响应，并根据 total 与 size 计算 total_pages。
+func Page(c *gin.Context, items interface{}, page, size int, total int64) {
+	Success(c, NewPageData(items, page, size, total))
+}
@@ -0,0 +1,57 @@
+package response
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/yinqf/go-pkg/logger"
+)
+
+// requestIDKey 为 gin 上下文中保存 request id 的键。
+const requestIDKey = "github.com/yinqf/go-pkg/response/request_id"
+
+const maxRequestIDLength = 128
+
+// RequestID 读取或生成 X-Request-ID，写入 gin 上下文、请求上下文与响应头，响应体顶层同样携带 request_id。
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(logger.RequestIDHeader)
+		if !ValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Header(logger.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// GetRequestID 返回当前请求的 request id，未启用 RequestID 中间件时返回空字符串。
+func GetRequestID(c *gin.Context) string {
+	if c == nil {
+		return ""
+	}
+	if requestID := c.GetString(requestIDKey); requestID != "" {
+		return requestID
+	}
+	if c.Request != nil {
+		if requestID, ok := logger.RequestIDFromContext(c.Request.Context()); ok {
+			return requestID
+		}
+	}
+	return ""
+}
+
+// ValidRequestID 判断 request id 是否可用：仅接受长度受限的可见 ASCII 字符，避免日志注入。
+func ValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
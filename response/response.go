@@ -14,22 +14,33 @@ type Body struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data"`
+	// RequestID 为启用 RequestID 中间件时的请求 ID。
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func write(c *gin.Context, status, code int, msg string, data interface{}) {
-	c.JSON(status, Body{
-		Code:    code,
-		Message: msg,
-		Data:    data,
+	renderJSON(c, status, Body{
+		Code:      code,
+		Message:   localize(c, code, msg),
+		Data:      data,
+		RequestID: GetRequestID(c),
 	})
 }
 
 func Success(c *gin.Context, data interface{}) {
+	SuccessWithStatus(c, http.StatusOK, data)
+}
+
+// SuccessWithStatus 以指定的 2xx 状态码输出成功响应，例如创建返回 201、异步受理返回 202；非 2xx 时按 200 处理。
+func SuccessWithStatus(c *gin.Context, status int, data interface{}) {
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		status = http.StatusOK
+	}
 	if data == nil {
 		data = gin.H{}
 	}
 
-	write(c, http.StatusOK, SuccessCode, "OK", data)
+	write(c, status, SuccessCode, "OK", data)
 }
 
 func Error(c *gin.Context, msg string) {
@@ -45,6 +56,11 @@ func ErrorWithStatus(c *gin.Context, status int, msg string) {
 		msg = http.StatusText(status)
 	}
 
+	logFailure(c, status, msg, zap.Int("code", status))
+	write(c, status, status, msg, gin.H{})
+}
+
+func logFailure(c *gin.Context, status int, msg string, fields ...zap.Field) {
 	method := ""
 	requestURI := ""
 	if c.Request != nil {
@@ -54,13 +70,14 @@ func ErrorWithStatus(c *gin.Context, status int, msg string) {
 
 	logger.Error(
 		"请求处理失败",
-		zap.Int("status", status),
-		zap.String("message", msg),
-		zap.String("method", method),
-		zap.String("path", c.FullPath()),
-		zap.String("uri", requestURI),
-		zap.String("client_ip", c.ClientIP()),
+		append([]zap.Field{
+			zap.Int("status", status),
+			zap.String("message", msg),
+			zap.String("method", method),
+			zap.String("path", c.FullPath()),
+			zap.String("uri", requestURI),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("request_id", GetRequestID(c)),
+		}, fields...)...,
 	)
-
-	write(c, status, status, msg, gin.H{})
 }
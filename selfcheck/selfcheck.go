@@ -0,0 +1,97 @@
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/yinqf/go-pkg/auth"
+	"github.com/yinqf/go-pkg/database"
+	"github.com/yinqf/go-pkg/logger"
+	"github.com/yinqf/go-pkg/redis"
+)
+
+// Check 描述一个需要在启动时验证的子系统。
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result 记录单个子系统的检查结果。
+type Result struct {
+	Name     string        `json:"name"`
+	Healthy  bool          `json:"healthy"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report 汇总所有子系统的检查结果。
+type Report struct {
+	Healthy bool     `json:"healthy"`
+	Results []Result `json:"results"`
+}
+
+// Err 返回汇总后的错误，全部健康时返回 nil，便于作为启动闸门。
+func (r Report) Err() error {
+	if r.Healthy {
+		return nil
+	}
+	failed := make([]string, 0, len(r.Results))
+	for _, result := range r.Results {
+		if !result.Healthy {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.Name, result.Error))
+		}
+	}
+	return errors.New("selfcheck failed: " + strings.Join(failed, "; "))
+}
+
+// Run 依次执行检查并返回汇总报告，某一项失败不会中断其余检查。
+func Run(ctx context.Context, checks ...Check) Report {
+	report := Report{Healthy: true, Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		start := time.Now()
+		err := check.Run(ctx)
+		result := Result{Name: check.Name, Healthy: err == nil, Duration: time.Since(start)}
+		if err != nil {
+			result.Error = err.Error()
+			report.Healthy = false
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// Default 返回数据库、Redis、JWT 密钥与日志目录的标准检查项。
+func Default(client goredis.UniversalClient) []Check {
+	return []Check{Database(), Redis(client), JWTSecret(), Logger()}
+}
+
+// Database 检查数据库单例是否可达。
+func Database() Check {
+	return Check{Name: "database", Run: database.Ping}
+}
+
+// Redis 检查 Redis 客户端是否可达。
+func Redis(client goredis.UniversalClient) Check {
+	return Check{Name: "redis", Run: func(ctx context.Context) error {
+		return redis.Healthy(ctx, client)
+	}}
+}
+
+// JWTSecret 检查 JWT 签名密钥是否已配置。
+func JWTSecret() Check {
+	return Check{Name: "jwt_secret", Run: func(context.Context) error {
+		return auth.CheckSecret()
+	}}
+}
+
+// Logger 检查日志目录是否可写。
+func Logger() Check {
+	return Check{Name: "logger", Run: func(context.Context) error {
+		return logger.CheckWritable()
+	}}
+}
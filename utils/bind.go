@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// BindError 表示参数校验失败，Fields 为字段名（取 json/form 标签）到提示信息的映射，可直接作为响应数据返回。
+type BindError struct {
+	Fields map[string]string
+}
+
+func (e *BindError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+" "+e.Fields[name])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// BindJSON 将请求体绑定到新的 T 并执行校验，校验失败时返回 *BindError，请求体格式错误时返回原始错误。
+func BindJSON[T any](c *gin.Context) (*T, error) {
+	return bindWith[T](c, binding.JSON, "json")
+}
+
+// BindQuery 将查询参数绑定到新的 T 并执行校验，错误约定与 BindJSON 相同。
+func BindQuery[T any](c *gin.Context) (*T, error) {
+	return bindWith[T](c, binding.Query, "form")
+}
+
+func bindWith[T any](c *gin.Context, b binding.Binding, tag string) (*T, error) {
+	if c == nil {
+		return nil, errors.New("invalid context")
+	}
+
+	target := new(T)
+	if err := c.ShouldBindWith(target, b); err != nil {
+		var verrs validator.ValidationErrors
+		if errors.As(err, &verrs) {
+			return nil, translateValidationErrors(reflect.TypeOf(target), verrs, tag)
+		}
+		return nil, err
+	}
+	return target, nil
+}
+
+// translateValidationErrors 将 validator 的错误转换为字段到中文提示的映射。
+func translateValidationErrors(typ reflect.Type, verrs validator.ValidationErrors, tag string) *BindError {
+	fields := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		fields[fieldPath(typ, fe.StructNamespace(), tag)] = validationMessage(fe)
+	}
+	return &BindError{Fields: fields}
+}
+
+// fieldPath 沿结构体命名空间（如 User.Address.City）解析出使用标签名的字段路径，无法解析的部分保留原名。
+func fieldPath(typ reflect.Type, namespace string, tag string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 1 {
+		// 首段为类型名。
+		segments = segments[1:]
+	}
+
+	names := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index, _ := strings.Cut(segment, "[")
+		for typ != nil && (typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map) {
+			typ = typ.Elem()
+		}
+
+		label := name
+		if typ != nil && typ.Kind() == reflect.Struct {
+			if field, ok := typ.FieldByName(name); ok {
+				if tagName, _, _ := strings.Cut(field.Tag.Get(tag), ","); tagName != "" && tagName != "-" {
+					label = tagName
+				}
+				typ = field.Type
+			} else {
+				typ = nil
+			}
+		}
+		if index != "" {
+			label += "[" + index
+		}
+		names = append(names, label)
+	}
+	return strings.Join(names, ".")
+}
+
+// validationMessage 返回常见校验规则的中文提示，其余规则给出规则名。
+func validationMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	sized := false
+	switch fe.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		sized = true
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "不能为空"
+	case "email":
+		return "必须是有效的邮箱地址"
+	case "oneof":
+		return fmt.Sprintf("必须是 [%s] 之一", param)
+	case "min", "gte":
+		if sized {
+			return fmt.Sprintf("长度不能小于 %s", param)
+		}
+		return fmt.Sprintf("不能小于 %s", param)
+	case "max", "lte":
+		if sized {
+			return fmt.Sprintf("长度不能大于 %s", param)
+		}
+		return fmt.Sprintf("不能大于 %s", param)
+	case "gt":
+		return fmt.Sprintf("必须大于 %s", param)
+	case "lt":
+		return fmt.Sprintf("必须小于 %s", param)
+	case "len":
+		return fmt.Sprintf("长度必须为 %s", param)
+	default:
+		if param != "" {
+			return fmt.Sprintf("校验失败（%s=%s）", fe.Tag(), param)
+		}
+		return fmt.Sprintf("校验失败（%s）", fe.Tag())
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "github.com/yinqf/go-pkg/response"
+
+// PageResult 为类型安全的分页结果，替代手工拼装的 gin.H。
+type PageResult[T any] struct {
+	List  []T
+	Page  int
+	Size  int
+	Total int64
+}
+
+// Fill 填充 Service.Paginate 的返回值，可直接接收其调用结果：
+//
+//	result, err := utils.PageResult[User]{Page: page, Size: size}.Fill(svc.Paginate(ctx, page, size, filters, orders))
+func (r PageResult[T]) Fill(list []T, total int64, err error) (PageResult[T], error) {
+	if err != nil {
+		return r, err
+	}
+	r.List = list
+	r.Total = total
+	return r, nil
+}
+
+// ToResponse 转换为统一的分页响应结构，空列表输出为 [] 而非 null。
+func (r PageResult[T]) ToResponse() response.PageData {
+	list := r.List
+	if list == nil {
+		list = []T{}
+	}
+	return response.NewPageData(list, r.Page, r.Size, r.Total)
+}
@@ -3,25 +3,52 @@ package utils
 import (
 	"errors"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ParsePageAndSize 解析分页参数，返回 page/size 或错误。
+// DefaultMaxPageSize 为 size 参数的默认上限。
+const DefaultMaxPageSize = 100
+
+var maxPageSize atomic.Int64
+
+func init() {
+	maxPageSize.Store(DefaultMaxPageSize)
+}
+
+// SetMaxPageSize 设置 size 参数的上限，n <= 0 时恢复默认值 100。
+func SetMaxPageSize(n int) {
+	if n <= 0 {
+		n = DefaultMaxPageSize
+	}
+	maxPageSize.Store(int64(n))
+}
+
+// ParsePageAndSize 解析分页参数，返回 page/size 或错误；size 超过上限时截断为上限而不报错。
 func ParsePageAndSize(c *gin.Context) (int, int, error) {
+	page, size, _, err := ParsePageAndSizeClamped(c)
+	return page, size, err
+}
+
+// ParsePageAndSizeClamped 与 ParsePageAndSize 相同，额外返回 size 是否被截断为上限（见 SetMaxPageSize）。
+func ParsePageAndSizeClamped(c *gin.Context) (int, int, bool, error) {
 	if c == nil {
-		return 0, 0, errors.New("invalid context")
+		return 0, 0, false, errors.New("invalid context")
 	}
 
 	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
 	if err != nil || page < 1 {
-		return 0, 0, errors.New("page 必须为正整数")
+		return 0, 0, false, errors.New("page 必须为正整数")
 	}
 
 	size, err := strconv.Atoi(c.DefaultQuery("size", "10"))
 	if err != nil || size <= 0 {
-		return 0, 0, errors.New("size 必须为正整数")
+		return 0, 0, false, errors.New("size 必须为正整数")
 	}
 
-	return page, size, nil
+	if limit := int(maxPageSize.Load()); size > limit {
+		return page, limit, true, nil
+	}
+	return page, size, false, nil
 }